@@ -0,0 +1,459 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/query"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// primitive describes Go representation of primitive YDB type.
+type primitive struct {
+	// name is a name of the type constant in types package.
+	name string
+
+	// goType is a type of struct fields.
+	goType string
+
+	// ctor is a name of function of types package which makes value
+	// from goType.
+	ctor string
+}
+
+var primitives = map[value.PrimitiveType]primitive{
+	value.TypeBool:         {"TypeBool", "bool", "BoolValue"},
+	value.TypeInt8:         {"TypeInt8", "int8", "Int8Value"},
+	value.TypeUint8:        {"TypeUint8", "uint8", "Uint8Value"},
+	value.TypeInt16:        {"TypeInt16", "int16", "Int16Value"},
+	value.TypeUint16:       {"TypeUint16", "uint16", "Uint16Value"},
+	value.TypeInt32:        {"TypeInt32", "int32", "Int32Value"},
+	value.TypeUint32:       {"TypeUint32", "uint32", "Uint32Value"},
+	value.TypeInt64:        {"TypeInt64", "int64", "Int64Value"},
+	value.TypeUint64:       {"TypeUint64", "uint64", "Uint64Value"},
+	value.TypeFloat:        {"TypeFloat", "float32", "FloatValue"},
+	value.TypeDouble:       {"TypeDouble", "float64", "DoubleValue"},
+	value.TypeDate:         {"TypeDate", "time.Time", "DateValueFromTime"},
+	value.TypeDatetime:     {"TypeDatetime", "time.Time", "DatetimeValueFromTime"},
+	value.TypeTimestamp:    {"TypeTimestamp", "time.Time", "TimestampValueFromTime"},
+	value.TypeInterval:     {"TypeInterval", "time.Duration", "IntervalValueFromDuration"},
+	value.TypeTzDate:       {"TypeTzDate", "time.Time", "TzDateValueFromTime"},
+	value.TypeTzDatetime:   {"TypeTzDatetime", "time.Time", "TzDatetimeValueFromTime"},
+	value.TypeTzTimestamp:  {"TypeTzTimestamp", "time.Time", "TzTimestampValueFromTime"},
+	value.TypeString:       {"TypeString", "[]byte", "StringValue"},
+	value.TypeUTF8:         {"TypeUTF8", "string", "UTF8Value"},
+	value.TypeYSON:         {"TypeYSON", "[]byte", "YSONValueFromBytes"},
+	value.TypeJSON:         {"TypeJSON", "string", "JSONValue"},
+	value.TypeUUID:         {"TypeUUID", "[16]byte", "UUIDValue"},
+	value.TypeJSONDocument: {"TypeJSONDocument", "string", "JSONDocumentValue"},
+	value.TypeDyNumber:     {"TypeDyNumber", "string", "DyNumberValue"},
+}
+
+// goType returns Go type of struct field for column of type t.
+// Optional primitives and decimals are pointers, other types which have no
+// native Go representation are types.Value.
+func goType(t types.Type) string {
+	if optional, ok := t.(value.OptionalType); ok {
+		if g := goType(optional.T); g != "types.Value" {
+			return "*" + g
+		}
+		return "types.Value"
+	}
+	switch t := t.(type) {
+	case value.PrimitiveType:
+		if p, ok := primitives[t]; ok {
+			return p.goType
+		}
+	case value.DecimalType:
+		return "types.Decimal"
+	}
+	return "types.Value"
+}
+
+// typeExpr returns Go expression which makes type t.
+func typeExpr(t types.Type) string {
+	switch t := t.(type) {
+	case value.PrimitiveType:
+		return "types." + primitives[t].name
+	case value.DecimalType:
+		return fmt.Sprintf("types.DecimalType(%d, %d)", t.Precision, t.Scale)
+	case value.OptionalType:
+		return "types.Optional(" + typeExpr(t.T) + ")"
+	case value.ListType:
+		return "types.List(" + typeExpr(t.T) + ")"
+	case value.DictType:
+		return "types.Dict(" + typeExpr(t.Key) + ", " + typeExpr(t.Payload) + ")"
+	case value.TupleType:
+		elems := make([]string, len(t.Elems))
+		for i, e := range t.Elems {
+			elems[i] = typeExpr(e)
+		}
+		return "types.Tuple(" + strings.Join(elems, ", ") + ")"
+	case value.StructType:
+		return "types.Struct(" + structFieldsExpr(t.Fields) + ")"
+	case value.VariantType:
+		if t.S.Fields != nil {
+			return "types.Variant(" + typeExpr(t.S) + ")"
+		}
+		return "types.Variant(" + typeExpr(t.T) + ")"
+	case value.VoidType:
+		return "types.Void()"
+	}
+	panic(fmt.Sprintf("ydbgen: unsupported type %s", t))
+}
+
+func structFieldsExpr(fields []value.StructField) string {
+	xs := make([]string, len(fields))
+	for i, f := range fields {
+		xs[i] = fmt.Sprintf("\n\ttypes.StructField(%q, %s)", f.Name, typeExpr(f.Type))
+	}
+	return strings.Join(xs, ",") + ",\n"
+}
+
+// generator writes Go code of tables.
+type generator struct {
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// value writes statements which assign value of Go expression x of column
+// type t to new variable dst.
+func (g *generator) value(dst, x string, t types.Type) {
+	if goType(t) == "types.Value" {
+		g.printf("%s := %s\n", dst, x)
+		g.printf("if %s == nil {\n", dst)
+		if optional, ok := t.(value.OptionalType); ok {
+			g.printf("%s = types.NullValue(%s)\n", dst, typeExpr(optional.T))
+		} else {
+			g.printf("%s = types.ZeroValue(%s)\n", dst, typeExpr(t))
+		}
+		g.printf("}\n")
+		return
+	}
+	optional, ok := t.(value.OptionalType)
+	if !ok {
+		g.printf("%s := %s\n", dst, valueExpr(x, t, false))
+		return
+	}
+	g.printf("%s := types.NullValue(%s)\n", dst, typeExpr(optional.T))
+	g.printf("if %s != nil {\n", x)
+	g.printf("%s = types.OptionalValue(%s)\n", dst, valueExpr(x, optional.T, true))
+	g.printf("}\n")
+}
+
+// valueExpr returns expression which makes value of primitive or decimal
+// type t from Go expression x. If ptr is true then x is a pointer.
+func valueExpr(x string, t types.Type, ptr bool) string {
+	if _, ok := t.(value.DecimalType); ok {
+		if !ptr {
+			x = "&" + x
+		}
+		return "types.DecimalValue(" + x + ")"
+	}
+	if ptr {
+		x = "*" + x
+	}
+	return "types." + primitives[t.(value.PrimitiveType)].ctor + "(" + x + ")"
+}
+
+func (g *generator) file(s *Schema) ([]byte, error) {
+	// Package time is used by fields of generated structs and by primary key
+	// arguments of functions, which are generated for existing structs too.
+	var needTime bool
+	for _, t := range s.Tables {
+		if !t.exists {
+			for _, c := range t.Columns {
+				if strings.Contains(goType(c.t), "time.") {
+					needTime = true
+				}
+			}
+		}
+		for _, k := range t.PrimaryKey {
+			if strings.Contains(goType(t.column(k).t), "time.") {
+				needTime = true
+			}
+		}
+	}
+	g.printf("// Code generated by ydbgen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", s.Package)
+	g.printf("import (\n")
+	g.printf("%q\n", "context")
+	if needTime {
+		g.printf("%q\n", "time")
+	}
+	g.printf("\n")
+	g.printf("%q\n", "github.com/ydb-platform/ydb-go-sdk/v3/table")
+	g.printf("%q\n", "github.com/ydb-platform/ydb-go-sdk/v3/table/resultset")
+	g.printf("%q\n", "github.com/ydb-platform/ydb-go-sdk/v3/table/types")
+	g.printf(")\n")
+	for i := range s.Tables {
+		g.table(&s.Tables[i])
+	}
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) table(t *Table) {
+	var (
+		name    = t.Type
+		columns = make([]string, len(t.Columns))
+	)
+	for i, c := range t.Columns {
+		columns[i] = c.Name
+	}
+
+	if !t.exists {
+		g.printf("\n// %s is a row of table %q.\n", name, t.Path)
+		g.printf("type %s struct {\n", name)
+		for _, c := range t.Columns {
+			g.printf("%s %s `ydb:%q`\n", c.Field, goType(c.t), c.Name)
+		}
+		g.printf("}\n")
+	}
+
+	g.printf("\n// %sTable is a path of table %q.\n", name, t.Path)
+	g.printf("const %sTable = %q\n", name, t.Path)
+
+	g.printf("\n// %sColumns are names of columns of table %q in order of %s fields.\n", name, t.Path, name)
+	g.printf("var %sColumns = []string{", name)
+	for i, c := range columns {
+		if i > 0 {
+			g.printf(", ")
+		}
+		g.printf("%q", c)
+	}
+	g.printf("}\n")
+
+	g.printf("\n// %sType is a type of rows of table %q.\n", name, t.Path)
+	fields := make([]value.StructField, len(t.Columns))
+	for i, c := range t.Columns {
+		fields[i] = value.StructField{Name: c.Name, Type: c.t}
+	}
+	g.printf("var %sType = types.Struct(%s)\n", name, structFieldsExpr(fields))
+
+	g.printf("\n// Scan scans current row of res into r.\n")
+	g.printf("// Columns of the result set must be selected as %sColumns.\n", name)
+	g.printf("func (r *%s) Scan(res resultset.Result) error {\n", name)
+	g.printf("return res.Scan(\n")
+	for _, c := range t.Columns {
+		g.printf("&r.%s,\n", c.Field)
+	}
+	g.printf(")\n}\n")
+
+	g.printf("\nfunc (r *%s) values() []types.Value {\n", name)
+	for i, c := range t.Columns {
+		g.value(fmt.Sprintf("v%d", i), "r."+c.Field, c.t)
+	}
+	g.printf("return []types.Value{")
+	for i := range t.Columns {
+		if i > 0 {
+			g.printf(", ")
+		}
+		g.printf("v%d", i)
+	}
+	g.printf("}\n}\n")
+
+	g.printf("\n// Value returns r as a value of %sType.\n", name)
+	g.printf("func (r *%s) Value() types.Value {\n", name)
+	g.printf("values := r.values()\n")
+	g.printf("fields := make([]types.StructValueOption, len(values))\n")
+	g.printf("for i, v := range values {\n")
+	g.printf("fields[i] = types.StructFieldValue(%sColumns[i], v)\n", name)
+	g.printf("}\n")
+	g.printf("return types.StructValue(fields...)\n}\n")
+
+	g.printf("\n// Params returns fields of r as query parameters named by position of\n")
+	g.printf("// column in %sColumns, as column names may be not valid names of parameters:\n", name)
+	g.printf("//\n")
+	for i, c := range t.Columns {
+		g.printf("//\t%s %s\n", columnParam(i), c.Name)
+	}
+	g.printf("func (r *%s) Params() *table.QueryParameters {\n", name)
+	g.printf("values := r.values()\n")
+	g.printf("return table.NewQueryParameters(\n")
+	for i := range t.Columns {
+		g.printf("table.ValueParam(%q, values[%d]),\n", columnParam(i), i)
+	}
+	g.printf(")\n}\n")
+
+	g.printf("\n// %sListValue returns rows as List<%sType> value.\n", name, name)
+	g.printf("func %sListValue(rows []%s) types.Value {\n", name, name)
+	g.printf("if len(rows) == 0 {\n")
+	g.printf("return types.ZeroValue(types.List(%sType))\n", name)
+	g.printf("}\n")
+	g.printf("values := make([]types.Value, len(rows))\n")
+	g.printf("for i := range rows {\n")
+	g.printf("values[i] = rows[i].Value()\n")
+	g.printf("}\n")
+	g.printf("return types.ListValue(values...)\n}\n")
+
+	g.printf("\n// Read%s reads rows of the next result set of res.\n", name)
+	g.printf("func Read%s(ctx context.Context, res resultset.Result) (rows []%s, err error) {\n", name, name)
+	g.printf("if !res.NextResultSet(ctx, %sColumns...) {\n", name)
+	g.printf("return nil, res.Err()\n")
+	g.printf("}\n")
+	g.printf("for res.NextRow() {\n")
+	g.printf("var r %s\n", name)
+	g.printf("if err = r.Scan(res); err != nil {\n")
+	g.printf("return nil, err\n")
+	g.printf("}\n")
+	g.printf("rows = append(rows, r)\n")
+	g.printf("}\n")
+	g.printf("return rows, res.Err()\n}\n")
+
+	g.upsert(t)
+	if len(t.PrimaryKey) > 0 {
+		g.get(t, columns)
+		g.delete(t)
+	}
+}
+
+func (g *generator) upsert(t *Table) {
+	fields := make([]value.StructField, len(t.Columns))
+	for i, c := range t.Columns {
+		fields[i] = value.StructField{Name: c.Name, Type: c.t}
+	}
+	q := fmt.Sprintf(
+		"DECLARE $rows AS %s;\nUPSERT INTO %s SELECT * FROM AS_TABLE($rows);",
+		value.FormatTypeYQL(value.ListType{T: value.StructType{Fields: fields}}), query.Quote(t.Path),
+	)
+	name := t.Type
+	g.printf("\n// Upsert%s upserts rows into table %q.\n", name, t.Path)
+	g.printf("func Upsert%s(ctx context.Context, s table.Session, tx *table.TransactionControl, rows []%s) error {\n", name, name)
+	g.printf("_, res, err := s.Execute(ctx, tx, %s, table.NewQueryParameters(\n", strconv.Quote(q))
+	g.printf("table.ValueParam(\"$rows\", %sListValue(rows)),\n", name)
+	g.printf("))\n")
+	g.printf("if err != nil {\nreturn err\n}\n")
+	g.printf("return res.Close()\n}\n")
+}
+
+// keyArgs writes primary key parameters of function and returns text of
+// declarations and condition of query. Query parameters are named by position
+// of key column as keyParam does, since column names may be not valid names
+// of parameters.
+func (g *generator) keyArgs(t *Table) (declare, where string, args []string) {
+	var (
+		d strings.Builder
+		w strings.Builder
+	)
+	for i, k := range t.PrimaryKey {
+		c := t.column(k)
+		arg := argName(c.Field)
+		args = append(args, arg)
+		if i > 0 {
+			g.printf(", ")
+			w.WriteString(" AND ")
+		}
+		g.printf("%s %s", arg, goType(c.t))
+		fmt.Fprintf(&d, "DECLARE %s AS %s;\n", keyParam(i), value.FormatTypeYQL(c.t))
+		fmt.Fprintf(&w, "%s = %s", query.Quote(c.Name), keyParam(i))
+	}
+	return d.String(), w.String(), args
+}
+
+func (g *generator) keyParams(t *Table, args []string) {
+	for i, k := range t.PrimaryKey {
+		g.value(fmt.Sprintf("k%d", i), args[i], t.column(k).t)
+	}
+	g.printf("params := table.NewQueryParameters(\n")
+	for i := range t.PrimaryKey {
+		g.printf("table.ValueParam(%q, k%d),\n", keyParam(i), i)
+	}
+	g.printf(")\n")
+}
+
+func (g *generator) get(t *Table, columns []string) {
+	name := t.Type
+	g.printf("\n// Get%s returns row of table %q with given primary key.\n", name, t.Path)
+	g.printf("// It returns nil if there is no such row.\n")
+	g.printf("func Get%s(ctx context.Context, s table.Session, tx *table.TransactionControl, ", name)
+	declare, where, args := g.keyArgs(t)
+	g.printf(") (*%s, error) {\n", name)
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = query.Quote(c)
+	}
+	q := fmt.Sprintf(
+		"%sSELECT %s FROM %s WHERE %s;",
+		declare, strings.Join(names, ", "), query.Quote(t.Path), where,
+	)
+	g.keyParams(t, args)
+	g.printf("_, res, err := s.Execute(ctx, tx, %s, params)\n", strconv.Quote(q))
+	g.printf("if err != nil {\nreturn nil, err\n}\n")
+	g.printf("defer func() {\n_ = res.Close()\n}()\n")
+	g.printf("rows, err := Read%s(ctx, res)\n", name)
+	g.printf("if err != nil || len(rows) == 0 {\nreturn nil, err\n}\n")
+	g.printf("return &rows[0], nil\n}\n")
+}
+
+func (g *generator) delete(t *Table) {
+	name := t.Type
+	g.printf("\n// Delete%s deletes row of table %q with given primary key.\n", name, t.Path)
+	g.printf("func Delete%s(ctx context.Context, s table.Session, tx *table.TransactionControl, ", name)
+	declare, where, args := g.keyArgs(t)
+	g.printf(") error {\n")
+	q := fmt.Sprintf("%sDELETE FROM %s WHERE %s;", declare, query.Quote(t.Path), where)
+	g.keyParams(t, args)
+	g.printf("_, res, err := s.Execute(ctx, tx, %s, params)\n", strconv.Quote(q))
+	g.printf("if err != nil {\nreturn err\n}\n")
+	g.printf("return res.Close()\n}\n")
+}
+
+// keyParam returns name of query parameter of i-th primary key column.
+func keyParam(i int) string {
+	return fmt.Sprintf("$p%d", i)
+}
+
+// columnParam returns name of query parameter of i-th column in Params.
+func columnParam(i int) string {
+	return fmt.Sprintf("$p%d", i)
+}
+
+func (t *Table) column(name string) *Column {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// argName returns name of function argument made from field name.
+func argName(field string) string {
+	n := 0
+	for n < len(field) && field[n] >= 'A' && field[n] <= 'Z' {
+		n++
+	}
+	if n > 1 && n < len(field) {
+		// Keep the first letter of the next word, e.g. IDName -> idName.
+		n--
+	}
+	arg := strings.ToLower(field[:n]) + field[n:]
+	switch {
+	case token.IsKeyword(arg), reserved[arg]:
+		arg += "Key"
+	}
+	return arg
+}
+
+// reserved are names used by generated functions.
+var reserved = map[string]bool{
+	"ctx":    true,
+	"s":      true,
+	"tx":     true,
+	"res":    true,
+	"rows":   true,
+	"err":    true,
+	"params": true,
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate(&Schema{
+		Package: "db",
+		Tables: []Table{
+			{
+				Path: "dir/series",
+				Columns: []Column{
+					{Name: "series_id", Type: "Uint64"},
+					{Name: "title", Type: "Utf8?"},
+					{Name: "release_date", Type: "Date"},
+					{Name: "price", Type: "Optional<Decimal(22,9)>"},
+					{Name: "tags", Type: "List<Utf8>"},
+				},
+				PrimaryKey: []string{"series_id"},
+			},
+			{
+				Path: "events",
+				Type: "Event",
+				Columns: []Column{
+					{Name: "data", Type: "Json", Field: "Payload"},
+				},
+			},
+			{
+				Path: "kv",
+				Type: "KV",
+				Columns: []Column{
+					{Name: "key-id", Type: "Utf8"},
+					{Name: "select", Type: "Int32"},
+				},
+				PrimaryKey: []string{"key-id"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := string(src)
+	for _, exp := range []string{
+		"package db\n",
+		"\t\"time\"\n",
+		"type Series struct {\n" +
+			"\tSeriesID    uint64         `ydb:\"series_id\"`\n" +
+			"\tTitle       *string        `ydb:\"title\"`\n" +
+			"\tReleaseDate time.Time      `ydb:\"release_date\"`\n" +
+			"\tPrice       *types.Decimal `ydb:\"price\"`\n" +
+			"\tTags        types.Value    `ydb:\"tags\"`\n" +
+			"}\n",
+		"const SeriesTable = \"dir/series\"\n",
+		"\ttypes.StructField(\"price\", types.Optional(types.DecimalType(22, 9))),\n",
+		"\tv1 := types.NullValue(types.TypeUTF8)\n" +
+			"\tif r.Title != nil {\n" +
+			"\t\tv1 = types.OptionalValue(types.UTF8Value(*r.Title))\n" +
+			"\t}\n",
+		"\tv2 := types.DateValueFromTime(r.ReleaseDate)\n",
+		"\t\tv3 = types.OptionalValue(types.DecimalValue(r.Price))\n",
+		"\t\tv4 = types.ZeroValue(types.List(types.TypeUTF8))\n",
+		"func ReadSeries(ctx context.Context, res resultset.Result) (rows []Series, err error) {\n",
+		"func UpsertSeries(ctx context.Context, s table.Session, tx *table.TransactionControl, rows []Series) error {\n",
+		"DECLARE $rows AS List<Struct<`series_id`:Uint64,`title`:Optional<Utf8>,`release_date`:Date," +
+			"`price`:Optional<Decimal(22,9)>,`tags`:List<Utf8>>>;\\nUPSERT INTO `dir/series` SELECT * FROM AS_TABLE($rows);",
+		"func GetSeries(ctx context.Context, s table.Session, tx *table.TransactionControl, seriesID uint64) (*Series, error) {\n",
+		"DECLARE $p0 AS Uint64;\\nSELECT `series_id`, `title`, `release_date`, `price`, `tags` " +
+			"FROM `dir/series` WHERE `series_id` = $p0;",
+		"func DeleteSeries(ctx context.Context, s table.Session, tx *table.TransactionControl, seriesID uint64) error {\n",
+		"type Event struct {\n\tPayload string `ydb:\"data\"`\n}\n",
+		"\tv0 := types.JSONValue(r.Payload)\n",
+		"DECLARE $rows AS List<Struct<`key-id`:Utf8,`select`:Int32>>;\\nUPSERT INTO `kv` SELECT * FROM AS_TABLE($rows);",
+		"DECLARE $p0 AS Utf8;\\nDELETE FROM `kv` WHERE `key-id` = $p0;",
+		"//\t$p0 key-id\n" +
+			"//\t$p1 select\n" +
+			"func (r *KV) Params() *table.QueryParameters {\n" +
+			"\tvalues := r.values()\n" +
+			"\treturn table.NewQueryParameters(\n" +
+			"\t\ttable.ValueParam(\"$p0\", values[0]),\n" +
+			"\t\ttable.ValueParam(\"$p1\", values[1]),\n" +
+			"\t)\n",
+	} {
+		if !strings.Contains(code, exp) {
+			t.Errorf("generated code does not contain:\n%s", exp)
+		}
+	}
+	for _, unexp := range []string{"func GetEvent(", "func DeleteEvent("} {
+		if strings.Contains(code, unexp) {
+			t.Errorf("generated code contains %s for table without primary key", unexp)
+		}
+	}
+	build(t, map[string][]byte{"db.go": src})
+}
+
+// build compiles package of given files inside the module of SDK.
+func build(t *testing.T, files map[string][]byte) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping build of generated code in short mode")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command is not found")
+	}
+	dir, err := ioutil.TempDir(".", "generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, src := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), src, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(gobin, "vet", "./"+filepath.Base(dir))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated code is not compiled: %v\n%s", err, out)
+	}
+}
+
+func TestGenerateSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ydbgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "models.go")
+	err = ioutil.WriteFile(name, []byte(`package models
+
+import "time"
+
+// User is a user.
+//ydb:table users
+type User struct {
+	ID       uint64    `+"`ydb:\"id,pk\"`"+`
+	Name     *string
+	Birthday time.Time `+"`ydb:\"birthday,type=Date\"`"+`
+	Skip     int       `+"`ydb:\"-\"`"+`
+	internal int
+}
+
+type Other struct {
+	A int
+}
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := loadSource(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := string(src)
+	for _, exp := range []string{
+		"package models\n",
+		"var UserColumns = []string{\"id\", \"Name\", \"birthday\"}\n",
+		"\tv2 := types.DateValueFromTime(r.Birthday)\n",
+		"func GetUser(ctx context.Context, s table.Session, tx *table.TransactionControl, id uint64) (*User, error) {\n",
+	} {
+		if !strings.Contains(code, exp) {
+			t.Errorf("generated code does not contain:\n%s", exp)
+		}
+	}
+	for _, unexp := range []string{"type User struct", "\"time\"", "Other"} {
+		if strings.Contains(code, unexp) {
+			t.Errorf("generated code contains %s", unexp)
+		}
+	}
+}
+
+func TestGenerateSourceTimeKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ydbgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	models := []byte(`package models
+
+import "time"
+
+//ydb:table events
+type Event struct {
+	Day   time.Time ` + "`ydb:\"day,pk,type=Date\"`" + `
+	Count uint64    ` + "`ydb:\"count\"`" + `
+}
+`)
+	name := filepath.Join(dir, "models.go")
+	if err = ioutil.WriteFile(name, models, 0600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := loadSource(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "func GetEvent(ctx context.Context, s table.Session, tx *table.TransactionControl, day time.Time) (*Event, error) {\n"; !strings.Contains(string(src), exp) {
+		t.Errorf("generated code does not contain:\n%s", exp)
+	}
+	build(t, map[string][]byte{
+		"models.go":     models,
+		"models_ydb.go": src,
+	})
+}
+
+func TestStructTableError(t *testing.T) {
+	for _, field := range []string{
+		"A int",
+		"A uint64 `ydb:\"a,type=Utf8\"`",
+		"A uint64 `ydb:\"a,unknown\"`",
+		"A map[string]int",
+	} {
+		t.Run(field, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "ydbgen")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			name := filepath.Join(dir, "models.go")
+			src := "package models\n\n//ydb:table t\ntype T struct {\n\t" + field + "\n}\n"
+			if err = ioutil.WriteFile(name, []byte(src), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = loadSource(name); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestResolveError(t *testing.T) {
+	for _, s := range []Schema{
+		{Tables: []Table{{Path: "t", Columns: []Column{{Name: "a", Type: "Int32"}}}}},
+		{Package: "db", Tables: []Table{{Columns: []Column{{Name: "a", Type: "Int32"}}}}},
+		{Package: "db", Tables: []Table{{Path: "t"}}},
+		{Package: "db", Tables: []Table{{Path: "t", Columns: []Column{{Name: "a", Type: "Int"}}}}},
+		{Package: "db", Tables: []Table{{Path: "t", Columns: []Column{{Name: "value", Type: "Int32"}}}}},
+		{Package: "db", Tables: []Table{{Path: "t", Columns: []Column{{Name: "a", Type: "Int32"}}, PrimaryKey: []string{"b"}}}},
+	} {
+		if _, err := generate(&s); err == nil {
+			t.Errorf("expected error for %+v", s)
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		field string
+		arg   string
+	}{
+		{"id", "ID", "id"},
+		{"series_id", "SeriesID", "seriesID"},
+		{"user-events", "UserEvents", "userEvents"},
+		{"url_path", "URLPath", "urlPath"},
+		{"type", "Type", "typeKey"},
+		{"1st", "X1st", "x1st"},
+		{"rows", "Rows", "rowsKey"},
+	} {
+		if field := goName(test.name); field != test.field {
+			t.Errorf("goName(%q) = %q, want %q", test.name, field, test.field)
+		}
+		if arg := argName(test.field); arg != test.arg {
+			t.Errorf("argName(%q) = %q, want %q", test.field, arg, test.arg)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Command ydbgen generates typed accessors of YDB tables.
+//
+// For each table it generates a struct of table row (unless the struct is
+// defined by user), scan and parameter helpers and functions which get,
+// upsert and delete rows with given table.Session:
+//
+//     type Users struct { ID uint64 `ydb:"id"`; Name *string `ydb:"name"` }
+//     func (r *Users) Scan(res resultset.Result) error
+//     func (r *Users) Value() types.Value
+//     func (r *Users) Params() *table.QueryParameters
+//     func UsersListValue(rows []Users) types.Value
+//     func ReadUsers(ctx context.Context, res resultset.Result) ([]Users, error)
+//     func GetUsers(ctx context.Context, s table.Session, tx *table.TransactionControl, id uint64) (*Users, error)
+//     func UpsertUsers(ctx context.Context, s table.Session, tx *table.TransactionControl, rows []Users) error
+//     func DeleteUsers(ctx context.Context, s table.Session, tx *table.TransactionControl, id uint64) error
+//
+// Get and Delete functions are generated for tables with primary key only.
+// Params names parameters by position of columns, e.g. $p0 for the first
+// column.
+//
+// Schema of tables is read from one of the sources:
+//
+//     ydbgen -schema tables.json -o tables_ydb.go
+//     ydbgen -source models.go -o models_ydb.go
+//     ydbgen -connection grpc://localhost:2136/?database=/local -package db -o tables_ydb.go users series
+//
+// The -schema file is JSON described in loadJSON. The -source file is Go file
+// with structs marked with //ydb:table comment (see loadSource), so ydbgen may
+// be used with go:generate:
+//
+//     //go:generate ydbgen -source $GOFILE -o models_ydb.go
+//
+// With -connection tables are described in the database, credentials are taken
+// from YDB_ACCESS_TOKEN_CREDENTIALS and YDB_ANONYMOUS_CREDENTIALS environment
+// variables.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+func main() {
+	var (
+		schemaFile = flag.String("schema", "", "JSON file with schema of tables")
+		sourceFile = flag.String("source", "", "Go file with structs marked with //ydb:table comment")
+		connection = flag.String("connection", "", "connection string of database to describe tables in")
+		pkg        = flag.String("package", "", "package name of generated file, overrides package of schema")
+		output     = flag.String("o", "", "output file, stdout if empty")
+		timeout    = flag.Duration("timeout", time.Minute, "timeout of describing tables")
+	)
+	flag.Parse()
+
+	var (
+		s   *Schema
+		err error
+	)
+	switch {
+	case *schemaFile != "":
+		s, err = loadJSON(*schemaFile)
+	case *sourceFile != "":
+		s, err = loadSource(*sourceFile)
+	case *connection != "":
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		s, err = describe(ctx, *connection, *pkg, flag.Args())
+		cancel()
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fatal(err)
+	}
+	if *pkg != "" {
+		s.Package = *pkg
+	}
+	src, err := generate(s)
+	if err != nil {
+		fatal(err)
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = ioutil.WriteFile(*output, src, 0644)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// generate returns Go code of schema tables.
+func generate(s *Schema) ([]byte, error) {
+	if err := s.resolve(); err != nil {
+		return nil, err
+	}
+	var g generator
+	return g.file(s)
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "ydbgen: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// Table describes a table to generate code for.
+type Table struct {
+	// Path is a path of the table used in queries.
+	Path string `json:"path"`
+
+	// Type is a name of generated Go type of table rows.
+	// It is made from the last element of Path if empty.
+	Type string `json:"type"`
+
+	Columns    []Column `json:"columns"`
+	PrimaryKey []string `json:"primary_key"`
+
+	// exists is true if Go type of the rows is defined by user.
+	exists bool
+}
+
+// Column describes a column of the table.
+type Column struct {
+	Name string `json:"name"`
+
+	// Type is a YQL type string such as "Uint64" or "Optional<Utf8>".
+	Type string `json:"type"`
+
+	// Field is a name of Go struct field.
+	// It is made from Name if empty.
+	Field string `json:"field"`
+
+	t types.Type
+}
+
+// Schema is a set of tables generated into a single Go file.
+type Schema struct {
+	Package string  `json:"package"`
+	Tables  []Table `json:"tables"`
+}
+
+// resolve parses column types and fills defaults.
+func (s *Schema) resolve() error {
+	if s.Package == "" {
+		return fmt.Errorf("package name is not set")
+	}
+	for i := range s.Tables {
+		t := &s.Tables[i]
+		if t.Path == "" {
+			return fmt.Errorf("table #%d has no path", i)
+		}
+		if t.Type == "" {
+			t.Type = goName(path.Base(t.Path))
+		}
+		if len(t.Columns) == 0 {
+			return fmt.Errorf("table %q has no columns", t.Path)
+		}
+		var (
+			columns = make(map[string]bool, len(t.Columns))
+			fields  = map[string]bool{
+				// Names of generated methods.
+				"Scan":   true,
+				"Value":  true,
+				"Params": true,
+				"values": true,
+			}
+		)
+		for j := range t.Columns {
+			c := &t.Columns[j]
+			if c.t == nil {
+				x, err := types.ParseType(c.Type)
+				if err != nil {
+					return fmt.Errorf("column %q of table %q: %w", c.Name, t.Path, err)
+				}
+				c.t = x
+			}
+			if c.Field == "" {
+				c.Field = goName(c.Name)
+			}
+			if fields[c.Field] {
+				return fmt.Errorf("column %q of table %q: field name %s is already used", c.Name, t.Path, c.Field)
+			}
+			columns[c.Name] = true
+			fields[c.Field] = true
+		}
+		for _, k := range t.PrimaryKey {
+			if !columns[k] {
+				return fmt.Errorf("primary key column %q of table %q is not defined", k, t.Path)
+			}
+		}
+	}
+	return nil
+}
+
+// loadJSON reads schema from JSON file such as
+//
+//     {
+//         "package": "db",
+//         "tables": [{
+//             "path": "users",
+//             "columns": [
+//                 {"name": "id", "type": "Uint64"},
+//                 {"name": "name", "type": "Optional<Utf8>"}
+//             ],
+//             "primary_key": ["id"]
+//         }]
+//     }
+func loadJSON(name string) (*Schema, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var s Schema
+	if err = json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &s, nil
+}
+
+// loadSource reads schema from Go source file. Structs marked with comment
+//
+//     //ydb:table <path>
+//
+// describe rows of tables. Exported fields are columns, which names and types
+// may be set with tag `ydb:"name,pk,type=<YQL type>"`, where type option must be
+// the last one. Types of columns are made from Go types of fields by default,
+// see goTypes.
+func loadSource(name string) (*Schema, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{
+		Package: f.Name.Name,
+	}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.TypeSpec)
+			doc := spec.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			p, ok := tablePath(doc)
+			if !ok {
+				continue
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return nil, fmt.Errorf("%s: %s is not a struct", fset.Position(spec.Pos()), spec.Name.Name)
+			}
+			t, err := structTable(st)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", fset.Position(spec.Pos()), spec.Name.Name, err)
+			}
+			t.Path = p
+			t.Type = spec.Name.Name
+			t.exists = true
+			s.Tables = append(s.Tables, *t)
+		}
+	}
+	if len(s.Tables) == 0 {
+		return nil, fmt.Errorf("%s: no structs marked with //ydb:table comment", name)
+	}
+	return s, nil
+}
+
+func tablePath(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, c := range doc.List {
+		if p := strings.TrimPrefix(c.Text, "//ydb:table "); p != c.Text {
+			return strings.TrimSpace(p), true
+		}
+	}
+	return "", false
+}
+
+func structTable(st *ast.StructType) (*Table, error) {
+	t := new(Table)
+	for _, f := range st.Fields.List {
+		for _, ident := range f.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			c := Column{
+				Name:  ident.Name,
+				Field: ident.Name,
+			}
+			var pk bool
+			if f.Tag != nil {
+				tag, err := strconv.Unquote(f.Tag.Value)
+				if err != nil {
+					return nil, err
+				}
+				opts, ok := reflect.StructTag(tag).Lookup("ydb")
+				if opts == "-" {
+					continue
+				}
+				if ok {
+					parts := strings.Split(opts, ",")
+					if parts[0] != "" {
+						c.Name = parts[0]
+					}
+				options:
+					for j, opt := range parts[1:] {
+						switch {
+						case opt == "pk":
+							pk = true
+						case strings.HasPrefix(opt, "type="):
+							// Type may contain commas, so it takes the rest of the tag.
+							c.Type = strings.TrimPrefix(strings.Join(parts[j+1:], ","), "type=")
+							break options
+						default:
+							return nil, fmt.Errorf("field %s: unknown option %q", ident.Name, opt)
+						}
+					}
+				}
+			}
+			expr := exprString(f.Type)
+			if c.Type == "" {
+				x, ok := goTypes[expr]
+				if !ok {
+					return nil, fmt.Errorf("field %s: type of %s column must be set with type option", ident.Name, expr)
+				}
+				c.Type = x
+			}
+			x, err := types.ParseType(c.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", ident.Name, err)
+			}
+			if g := goType(x); g != expr {
+				return nil, fmt.Errorf("field %s: column of type %s must be %s, not %s", ident.Name, c.Type, g, expr)
+			}
+			c.t = x
+			t.Columns = append(t.Columns, c)
+			if pk {
+				t.PrimaryKey = append(t.PrimaryKey, c.Name)
+			}
+		}
+	}
+	return t, nil
+}
+
+// goTypes maps Go types of struct fields to default YQL types of columns.
+var goTypes = map[string]string{
+	"bool":           "Bool",
+	"int8":           "Int8",
+	"int16":          "Int16",
+	"int32":          "Int32",
+	"int64":          "Int64",
+	"uint8":          "Uint8",
+	"uint16":         "Uint16",
+	"uint32":         "Uint32",
+	"uint64":         "Uint64",
+	"float32":        "Float",
+	"float64":        "Double",
+	"string":         "Utf8",
+	"[]byte":         "String",
+	"[16]byte":       "Uuid",
+	"time.Time":      "Timestamp",
+	"time.Duration":  "Interval",
+	"*bool":          "Optional<Bool>",
+	"*int8":          "Optional<Int8>",
+	"*int16":         "Optional<Int16>",
+	"*int32":         "Optional<Int32>",
+	"*int64":         "Optional<Int64>",
+	"*uint8":         "Optional<Uint8>",
+	"*uint16":        "Optional<Uint16>",
+	"*uint32":        "Optional<Uint32>",
+	"*uint64":        "Optional<Uint64>",
+	"*float32":       "Optional<Float>",
+	"*float64":       "Optional<Double>",
+	"*string":        "Optional<Utf8>",
+	"*[]byte":        "Optional<String>",
+	"*[16]byte":      "Optional<Uuid>",
+	"*time.Time":     "Optional<Timestamp>",
+	"*time.Duration": "Optional<Interval>",
+}
+
+func exprString(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return "*" + exprString(e.X)
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "[]" + exprString(e.Elt)
+		}
+		return "[" + exprString(e.Len) + "]" + exprString(e.Elt)
+	case *ast.BasicLit:
+		return e.Value
+	default:
+		return fmt.Sprintf("%T", e)
+	}
+}
+
+// describe reads schema of tables from database with DescribeTable.
+// Connection parameters are taken from connection string and environment
+// variables YDB_ACCESS_TOKEN_CREDENTIALS and YDB_ANONYMOUS_CREDENTIALS.
+func describe(ctx context.Context, connection, pkg string, paths []string) (*Schema, error) {
+	opts := []ydb.Option{
+		ydb.WithConnectionString(connection),
+	}
+	if token, ok := os.LookupEnv("YDB_ACCESS_TOKEN_CREDENTIALS"); ok {
+		opts = append(opts, ydb.WithAccessTokenCredentials(token))
+	}
+	if v, ok := os.LookupEnv("YDB_ANONYMOUS_CREDENTIALS"); ok && v == "1" {
+		opts = append(opts, ydb.WithAnonymousCredentials())
+	}
+	db, err := ydb.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close(ctx)
+	}()
+	s := &Schema{
+		Package: pkg,
+	}
+	for _, p := range paths {
+		var t Table
+		err = db.Table().Do(ctx, func(ctx context.Context, s table.Session) error {
+			desc, err := s.DescribeTable(ctx, path.Join(db.Name(), p))
+			if err != nil {
+				return err
+			}
+			t = Table{
+				Path:       p,
+				PrimaryKey: desc.PrimaryKey,
+			}
+			for _, c := range desc.Columns {
+				t.Columns = append(t.Columns, Column{
+					Name: c.Name,
+					Type: value.FormatTypeYQL(c.Type),
+					t:    c.Type,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe %q: %w", p, err)
+		}
+		s.Tables = append(s.Tables, t)
+	}
+	return s, nil
+}
+
+// goName returns exported Go name of column or table name such as
+// "series_id" or "user-events".
+func goName(name string) string {
+	var b strings.Builder
+	for _, w := range strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		if u := strings.ToUpper(w); initialisms[u] {
+			b.WriteString(u)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]))
+		b.WriteString(w[1:])
+	}
+	s := b.String()
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		s = "X" + s
+	}
+	return s
+}
+
+var initialisms = map[string]bool{
+	"API":  true,
+	"HTTP": true,
+	"ID":   true,
+	"IP":   true,
+	"JSON": true,
+	"SQL":  true,
+	"TTL":  true,
+	"URL":  true,
+	"UUID": true,
+	"YQL":  true,
+}
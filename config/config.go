@@ -48,6 +48,7 @@ type Config interface {
 	// regardless of the cancellation appropriate error will be returned to
 	// the client.
 	// If OperationTimeout is zero then no timeout is used.
+	// Timeout of a call may be overridden with operation.WithTimeout.
 	OperationTimeout() time.Duration
 
 	// OperationCancelAfter is the maximum amount of time a YDB server will process an
@@ -55,6 +56,7 @@ type Config interface {
 	// it succeeds appropriate error will be returned to the client; otherwise
 	// processing will be continued.
 	// If OperationCancelAfter is zero then no timeout is used.
+	// Cancel after of a call may be overridden with operation.WithCancelAfter.
 	OperationCancelAfter() time.Duration
 
 	// DiscoveryInterval is the frequency of background tasks of ydb endpoints
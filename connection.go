@@ -14,6 +14,7 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/logger"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/ratelimiter"
 	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"github.com/ydb-platform/ydb-go-sdk/v3/operation"
 	"github.com/ydb-platform/ydb-go-sdk/v3/scheme"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
@@ -37,6 +38,7 @@ type Connection interface {
 	Coordination() coordination.Client
 	RateLimiter() ratelimiter.Client
 	Discovery() discovery.Client
+	Operation() operation.Client
 }
 
 type db struct {
@@ -48,6 +50,7 @@ type db struct {
 	coordination lazyCoordination
 	ratelimiter  lazyRatelimiter
 	discovery    lazyDiscovery
+	operation    lazyOperation
 }
 
 func (db *db) Discovery() discovery.Client {
@@ -74,6 +77,7 @@ func (db *db) Close(ctx context.Context) error {
 	_ = db.Table().Close(ctx)
 	_ = db.Scheme().Close(ctx)
 	_ = db.Coordination().Close(ctx)
+	_ = db.Operation().Close(ctx)
 	return db.cluster.Close(ctx)
 }
 
@@ -93,6 +97,10 @@ func (db *db) RateLimiter() ratelimiter.Client {
 	return &db.ratelimiter
 }
 
+func (db *db) Operation() operation.Client {
+	return &db.operation
+}
+
 // New connects to name and return name runtime holder
 func New(ctx context.Context, opts ...Option) (_ Connection, err error) {
 	db := &db{}
@@ -130,6 +138,8 @@ func New(ctx context.Context, opts ...Option) (_ Connection, err error) {
 	db.ratelimiter.db = db
 	db.discovery.db = db
 	db.scheme.db = db
+	db.operation.db = db
 	db.discovery.trace = db.config.Trace()
+	db.table.warmUp(ctx)
 	return db, nil
 }
@@ -65,6 +65,10 @@ func FromBytes(bts []byte, precision, scale uint32) *big.Int {
 		v.Add(v, one)
 		v.Neg(v)
 	}
+	if IsInf(v) || IsNaN(v) {
+		// Special values are encoded regardless of precision.
+		return v
+	}
 	if v.CmpAbs(pow(ten, precision)) >= 0 {
 		if neg {
 			v.Set(neginf)
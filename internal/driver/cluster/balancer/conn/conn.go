@@ -191,11 +191,16 @@ func (c *conn) Invoke(ctx context.Context, method string, req interface{}, res i
 			cancel()
 		}
 	}()
-	if t := c.config.OperationTimeout(); t > 0 {
-		ctx = operation.WithTimeout(ctx, t)
+	// Operation parameters of the call override the driver-wide ones.
+	if _, ok := operation.ContextTimeout(ctx); !ok {
+		if t := c.config.OperationTimeout(); t > 0 {
+			ctx = operation.WithTimeout(ctx, t)
+		}
 	}
-	if t := c.config.OperationCancelAfter(); t > 0 {
-		ctx = operation.WithCancelAfter(ctx, t)
+	if _, ok := operation.ContextCancelAfter(ctx); !ok {
+		if t := c.config.OperationCancelAfter(); t > 0 {
+			ctx = operation.WithCancelAfter(ctx, t)
+		}
 	}
 
 	params := operation.ContextParams(ctx)
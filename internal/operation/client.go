@@ -0,0 +1,111 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Operation_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/cluster"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/errors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/operation"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+)
+
+const getOperationMethod = "/Ydb.Operation.V1.OperationService/GetOperation"
+
+type client struct {
+	cluster cluster.Cluster
+	service Ydb_Operation_V1.OperationServiceClient
+}
+
+func New(c cluster.Cluster) operation.Client {
+	return &client{
+		cluster: c,
+		service: Ydb_Operation_V1.NewOperationServiceClient(c),
+	}
+}
+
+func (c *client) Close(_ context.Context) error {
+	return nil
+}
+
+func (c *client) Get(ctx context.Context, id string) (op operation.Operation, err error) {
+	var response Ydb_Operations.GetOperationResponse
+	// Generated client drops response on error, but not ready or unsuccessfully
+	// completed operation is a regular operation state here.
+	err = c.cluster.Invoke(ctx, getOperationMethod, &Ydb_Operations.GetOperationRequest{
+		Id: id,
+	}, &response)
+	if err != nil && response.GetOperation().GetId() == "" {
+		return op, err
+	}
+	return operation.New(response.GetOperation()), nil
+}
+
+func (c *client) Cancel(ctx context.Context, id string) (err error) {
+	response, err := c.service.CancelOperation(ctx, &Ydb_Operations.CancelOperationRequest{
+		Id: id,
+	})
+	if err != nil {
+		return err
+	}
+	if response.GetStatus() != Ydb.StatusIds_SUCCESS {
+		return errors.NewOpError(errors.WithOEOperation(response))
+	}
+	return nil
+}
+
+func (c *client) Forget(ctx context.Context, id string) (err error) {
+	response, err := c.service.ForgetOperation(ctx, &Ydb_Operations.ForgetOperationRequest{
+		Id: id,
+	})
+	if err != nil {
+		return err
+	}
+	if response.GetStatus() != Ydb.StatusIds_SUCCESS {
+		return errors.NewOpError(errors.WithOEOperation(response))
+	}
+	return nil
+}
+
+func (c *client) List(ctx context.Context, kind string) (ops []operation.Operation, err error) {
+	request := Ydb_Operations.ListOperationsRequest{
+		Kind: kind,
+	}
+	for {
+		var response *Ydb_Operations.ListOperationsResponse
+		response, err = c.service.ListOperations(ctx, &request)
+		if err != nil {
+			return nil, err
+		}
+		if response.GetStatus() != Ydb.StatusIds_SUCCESS {
+			return nil, errors.NewOpError(errors.WithOEOperation(response))
+		}
+		for _, o := range response.GetOperations() {
+			ops = append(ops, operation.New(o))
+		}
+		if response.GetNextPageToken() == "" {
+			return ops, nil
+		}
+		request.PageToken = response.GetNextPageToken()
+	}
+}
+
+func (c *client) Wait(ctx context.Context, id string) (op operation.Operation, err error) {
+	for i := 0; ; i++ {
+		op, err = c.Get(ctx, id)
+		if err != nil {
+			return op, err
+		}
+		if op.Ready {
+			return op, op.Err
+		}
+		select {
+		case <-ctx.Done():
+			return op, ctx.Err()
+		case <-retry.SlowBackoff.Wait(i):
+		}
+	}
+}
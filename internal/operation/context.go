@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	public "github.com/ydb-platform/ydb-go-sdk/v3/operation"
 	"github.com/ydb-platform/ydb-go-sdk/v3/testutil/timeutil"
 )
 
@@ -41,48 +42,34 @@ func contextUntilDeadline(ctx context.Context) (time.Duration, bool) {
 	return 0, false
 }
 
-type ctxOpTimeoutKey struct{}
-
-type ctxOpCancelAfterKey struct{}
-
 type ctxOpModeKey struct{}
 
 // WithTimeout returns a copy of parent deadline in which YDB operation timeout
 // parameter is set to d. If parent deadline timeout is smaller than d, parent deadline
 // is returned.
 func WithTimeout(ctx context.Context, d time.Duration) context.Context {
-	if cur, ok := ContextTimeout(ctx); ok && d >= cur {
-		// The current timeout is already smaller than the new one.
-		return ctx
-	}
-	return context.WithValue(ctx, ctxOpTimeoutKey{}, d)
+	return public.WithTimeout(ctx, d)
 }
 
 // ContextTimeout returns the timeout within given deadline after which
 // YDB should try to cancel operation and return result regardless of the
 // cancelation.
 func ContextTimeout(ctx context.Context) (d time.Duration, ok bool) {
-	d, ok = ctx.Value(ctxOpTimeoutKey{}).(time.Duration)
-	return
+	return public.ContextTimeout(ctx)
 }
 
 // WithCancelAfter returns a copy of parent deadline in which YDB operation
 // cancel after parameter is set to d. If parent deadline cancelation timeout is smaller
 // than d, parent deadline deadline is returned.
 func WithCancelAfter(ctx context.Context, d time.Duration) context.Context {
-	if cur, ok := ContextCancelAfter(ctx); ok && d >= cur {
-		// The current cancelation timeout is already smaller than the new one.
-		return ctx
-	}
-	return context.WithValue(ctx, ctxOpCancelAfterKey{}, d)
+	return public.WithCancelAfter(ctx, d)
 }
 
 // ContextCancelAfter returns the timeout within given deadline after which
 // YDB should try to cancel operation and return result regardless of the
 // cancelation.
 func ContextCancelAfter(ctx context.Context) (d time.Duration, ok bool) {
-	d, ok = ctx.Value(ctxOpCancelAfterKey{}).(time.Duration)
-	return
+	return public.ContextCancelAfter(ctx)
 }
 
 // WithMode returns a copy of parent deadline in which YDB operation mode
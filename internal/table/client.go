@@ -3,6 +3,7 @@ package table
 import (
 	"container/list"
 	"context"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -25,6 +26,10 @@ var (
 	// that the client is full and requested operation is not able to complete.
 	ErrSessionPoolOverflow = errors.New("ydb: table: build client overflow")
 
+	// ErrSessionPoolOverloaded is returned by a client instance to indicate
+	// that the client is full and its wait queue is full too.
+	ErrSessionPoolOverloaded = table.ErrSessionPoolOverloaded
+
 	// ErrSessionUnknown is returned by a client instance to indicate that
 	// requested build does not exist within the client.
 	ErrSessionUnknown = errors.New("ydb: table: unknown build")
@@ -77,6 +82,13 @@ func newClient(
 		c.keeperDone = make(chan struct{})
 		go c.keeper()
 	}
+	if config.MinIdleSize() > 0 {
+		c.warmUpWake = make(chan struct{}, 1)
+		c.warmUpStop = make(chan struct{})
+		c.warmUpDone = make(chan struct{})
+		c.wakeUpWarmUp()
+		go c.warmUp()
+	}
 	onDone(c.limit, c.config.KeepAliveMinSize())
 	return c
 }
@@ -95,12 +107,24 @@ type client struct {
 	createInProgress int        // KIKIMR-9163: in-create-process counter
 	limit            int        // Upper bound for client size.
 	idle             *list.List // list<table.session>
-	waitq            *list.List // list<*chan table.session>
+	waitq            *list.List // list<*waiter>, ordered by priority.
+	waitSeq          uint64     // Sequence number of the last waiter.
+
+	// Statistics, see Stats().
+	createdTotal      uint64
+	closedTotal       uint64
+	keepAliveFailures uint64
+	waitCount         uint64
+	waitTime          time.Duration
 
 	keeperWake chan struct{} // Set by keeper.
 	keeperStop chan struct{}
 	keeperDone chan struct{}
 
+	warmUpWake chan struct{} // Buffered, signals that idle sessions were taken.
+	warmUpStop chan struct{}
+	warmUpDone chan struct{}
+
 	touchingDone chan struct{}
 
 	mu sync.Mutex
@@ -139,7 +163,10 @@ type Session interface {
 	Close(ctx context.Context) (err error)
 	IsClosed() bool
 	Status() string
+	Address() string
 	OnClose(f func(ctx context.Context))
+	OnShutdown(f func(ctx context.Context))
+	IsShutdown() bool
 }
 
 type createSessionResult struct {
@@ -209,7 +236,9 @@ func (c *client) createSession(ctx context.Context) (s Session, err error) {
 			onDone := trace.TableOnPoolSessionClose(c.config.Trace().Compose(trace.ContextTable(ctx)), ctx, r.s)
 
 			delete(c.index, r.s)
+			c.closedTotal++
 			c.notify(nil)
+			c.wakeUpWarmUp()
 
 			onDone()
 
@@ -218,10 +247,15 @@ func (c *client) createSession(ctx context.Context) (s Session, err error) {
 			}
 		})
 
+		r.s.OnShutdown(func(ctx context.Context) {
+			go c.nodeShutdown(deadline.ContextWithoutDeadline(ctx), r.s.Address())
+		})
+
 		// Slot for build already reserved early
 		c.mu.Lock()
-		c.index[r.s] = sessionInfo{}
+		c.index[r.s] = c.newSessionInfo(timeutil.Now())
 		c.createInProgress--
+		c.createdTotal++
 		c.mu.Unlock()
 
 		resCh <- r
@@ -244,22 +278,61 @@ func (c *client) createSession(ctx context.Context) (s Session, err error) {
 	}
 }
 
+// waiter is an element of the wait queue.
+type waiter struct {
+	ch       *chan Session
+	priority table.Priority
+	seq      uint64 // Order of arrival, kept while waiter re-enqueues.
+}
+
+// pushWaiter inserts w into the wait queue after all waiters with higher or
+// equal priority which came earlier and returns number of waiters before w.
+// p.mu must be held.
+func (c *client) pushWaiter(w *waiter) (el *list.Element, position int) {
+	position = c.waitq.Len()
+	for mark := c.waitq.Back(); mark != nil; mark = mark.Prev() {
+		x := mark.Value.(*waiter)
+		if x.priority > w.priority || x.priority == w.priority && x.seq < w.seq {
+			return c.waitq.InsertAfter(w, mark), position
+		}
+		position--
+	}
+	return c.waitq.PushFront(w), 0
+}
+
 // Get returns first idle build from the client and removes it from
 // there. If no items stored in client it creates new one by calling
 // build.CreateSession() method and returns it.
+//
+// If client is full Get waits for a session in the queue ordered by priority
+// from ctx (see table.WithPriority) until a session is released or ctx is
+// done, so waiting time must be limited with ctx. If wait queue limit is
+// reached Get returns ErrSessionPoolOverloaded.
 func (c *client) Get(ctx context.Context) (s Session, err error) {
 	var (
 		i = 0
 		t = c.config.Trace().Compose(trace.ContextTable(ctx))
+		w = waiter{
+			priority: table.ContextPriority(ctx),
+		}
+		waitStart time.Time // Zero until the first wait.
 	)
 
 	onDone := trace.TableOnPoolGet(t, ctx)
 	defer func() {
+		c.mu.Lock()
+		if s != nil {
+			c.use(s)
+		}
+		if !waitStart.IsZero() {
+			c.waitCount++
+			c.waitTime += timeutil.Now().Sub(waitStart)
+		}
+		c.mu.Unlock()
 		onDone(s, i, err)
 	}()
 
-	const maxAttempts = 100
-	for ; s == nil && err == nil && i < maxAttempts; i++ {
+	for ; s == nil && err == nil; i++ {
 		var (
 			ch *chan Session
 			el *list.Element // Element in the wait queue.
@@ -275,6 +348,7 @@ func (c *client) Get(ctx context.Context) (s Session, err error) {
 		c.mu.Unlock()
 
 		if s != nil {
+			c.wakeUpWarmUp()
 			return s, nil
 		}
 
@@ -293,37 +367,41 @@ func (c *client) Get(ctx context.Context) (s Session, err error) {
 		// be fair here and not to lock more goroutines than we could ship
 		// build to.
 		c.mu.Lock()
+		if waitStart.IsZero() {
+			// Only newcomers are limited, waiters which were woken up and
+			// missed a session keep their place in the queue.
+			if limit := c.config.WaitQueueLimit(); limit > 0 && c.waitq.Len() >= limit {
+				c.mu.Unlock()
+				return nil, ErrSessionPoolOverloaded
+			}
+			c.waitSeq++
+			w.seq = c.waitSeq
+			waitStart = timeutil.Now()
+		}
 		ch = c.getWaitCh()
-		el = c.waitq.PushBack(ch)
+		w.ch = ch
+		el, position := c.pushWaiter(&w)
 		c.mu.Unlock()
 
-		waitDone := trace.TableOnPoolWait(t, ctx)
+		waitDone := trace.TableOnPoolWait(t, ctx, int(w.priority), position)
 		var ok bool
 		select {
 		case s, ok = <-*ch:
 			// Note that race may occur and some goroutine may try to write
 			// build into channel after it was enqueued but before it being
-			// read here. In that case we will receive nil here and will retry.
+			// read here. In that case we will receive nil here and will retry
+			// keeping the place in the queue.
 			//
 			// The same way will work when some build become deleted - the
-			// nil value will be sent into the channel.
+			// nil value will be sent into the channel, so the slot of the
+			// deleted session may be taken by a new one.
 			if ok {
 				// Put only filled and not closed channel back to the client.
 				// That is, we need to avoid races on filling reused channel
 				// for the next waiter – build could be lost for a long time.
 				c.putWaitCh(ch)
 			}
-			waitDone(s, err)
-
-		case <-time.After(c.config.CreateSessionTimeout()):
-			// pass to next iteration
-			c.mu.Lock()
-			// Note that el can be already removed here while we were moving
-			// from reading from ch to this case. This does not make any
-			// difference – channel will be closed by notifying goroutine.
-			c.waitq.Remove(el)
-			c.mu.Unlock()
-			waitDone(s, err)
+			waitDone(s, timeutil.Now().Sub(waitStart), err)
 
 		case <-ctx.Done():
 			c.mu.Lock()
@@ -336,13 +414,10 @@ func (c *client) Get(ctx context.Context) (s Session, err error) {
 			if s != nil {
 				_ = c.Put(ctx, s)
 			}
-			waitDone(s, err)
+			waitDone(s, timeutil.Now().Sub(waitStart), err)
 			return nil, err
 		}
 	}
-	if s == nil && err == nil {
-		err = ErrNoProgress
-	}
 
 	return s, err
 }
@@ -362,7 +437,14 @@ func (c *client) Put(ctx context.Context, s Session) (err error) {
 		onDone(err)
 	}()
 
+	var (
+		now     = timeutil.Now()
+		recycle = s.IsShutdown() // Must be called without p.mu held.
+	)
 	c.mu.Lock()
+	if info, has := c.index[s]; has && !recycle {
+		recycle = info.mustRecycle(now)
+	}
 	switch {
 	case c.closed:
 		err = ErrSessionPoolClosed
@@ -370,14 +452,17 @@ func (c *client) Put(ctx context.Context, s Session) (err error) {
 	case c.idle.Len() >= c.limit:
 		err = ErrSessionPoolOverflow
 
+	case recycle:
+		// Session is closed below, its slot is released by OnClose handler.
+
 	default:
 		if !c.notify(s) {
-			c.pushIdle(s, timeutil.Now())
+			c.pushIdle(s, now)
 		}
 	}
 	c.mu.Unlock()
 
-	if err != nil {
+	if err != nil || recycle {
 		closeCtx, cancel := context.WithTimeout(deadline.ContextWithoutDeadline(ctx), c.config.DeleteTimeout())
 		_ = s.Close(closeCtx)
 		cancel()
@@ -436,6 +521,9 @@ func (c *client) Take(ctx context.Context, s Session) (took bool, err error) {
 	if !has {
 		err = ErrSessionUnknown
 	}
+	if took {
+		c.wakeUpWarmUp()
+	}
 
 	return took, err
 }
@@ -494,11 +582,18 @@ func (c *client) Close(ctx context.Context) (err error) {
 	if ch := c.keeperStop; ch != nil {
 		close(ch)
 	}
+	warmUpDone := c.warmUpDone
+	if ch := c.warmUpStop; ch != nil {
+		close(ch)
+	}
 	c.mu.Unlock()
 
 	if keeperDone != nil {
 		<-keeperDone
 	}
+	if warmUpDone != nil {
+		<-warmUpDone
+	}
 
 	c.mu.Lock()
 	idle := c.idle
@@ -510,8 +605,8 @@ func (c *client) Close(ctx context.Context) (err error) {
 	c.mu.Unlock()
 
 	for el := waitq.Front(); el != nil; el = el.Next() {
-		ch := el.Value.(*chan Session)
-		close(*ch)
+		w := el.Value.(*waiter)
+		close(*w.ch)
 	}
 	for e := idle.Front(); e != nil; e = e.Next() {
 		s := e.Value.(Session)
@@ -548,26 +643,100 @@ func (c *client) Do(ctx context.Context, op table.Operation, opts ...table.Optio
 	)
 }
 
-func (c *client) Stats() poolStats {
+func (c *client) Stats() table.Stats {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	idleCount, waitQCount, indexCount := 0, 0, 0
-	if c.idle != nil {
-		idleCount = c.idle.Len()
+	stats := table.Stats{
+		Waiting:           c.waitq.Len(),
+		CreateInProgress:  c.createInProgress,
+		MinSize:           c.keepAliveMinSize(),
+		MaxSize:           c.limit,
+		Created:           c.createdTotal,
+		Closed:            c.closedTotal,
+		KeepAliveFailures: c.keepAliveFailures,
+		Endpoints:         make(map[string]table.EndpointStats),
 	}
-	if c.waitq != nil {
-		waitQCount = c.waitq.Len()
+	if c.waitCount > 0 {
+		stats.AvgWaitTime = c.waitTime / time.Duration(c.waitCount)
 	}
-	if c.index != nil {
-		indexCount = len(c.index)
+	for s, info := range c.index {
+		e := stats.Endpoints[s.Address()]
+		if info.idle != nil {
+			stats.Idle++
+			e.Idle++
+		} else {
+			stats.InUse++
+			e.InUse++
+		}
+		stats.Endpoints[s.Address()] = e
 	}
-	return poolStats{
-		Idle:             idleCount,
-		Index:            indexCount,
-		WaitQ:            waitQCount,
-		CreateInProgress: c.createInProgress,
-		MinSize:          c.config.KeepAliveMinSize(),
-		MaxSize:          c.limit,
+	return stats
+}
+
+// RecycleEndpoint closes idle sessions bound to the endpoint and marks busy
+// ones to be closed by Put().
+func (c *client) RecycleEndpoint(ctx context.Context, address string) int {
+	idle, busy := c.recycleEndpoint(ctx, address)
+	return idle + busy
+}
+
+// recycleEndpoint returns numbers of closed idle and marked busy sessions.
+func (c *client) recycleEndpoint(ctx context.Context, address string) (idle, busy int) {
+	var toDelete []Session
+	c.mu.Lock()
+	for s, info := range c.index {
+		if s.Address() != address {
+			continue
+		}
+		if info.idle != nil {
+			_ = c.removeIdle(s)
+			toDelete = append(toDelete, s)
+			continue
+		}
+		info.recycle = true
+		c.index[s] = info
+		busy++
+	}
+	c.mu.Unlock()
+
+	for _, s := range toDelete {
+		closeCtx, cancel := context.WithTimeout(deadline.ContextWithoutDeadline(ctx), c.config.DeleteTimeout())
+		_ = s.Close(closeCtx)
+		cancel()
+	}
+	return len(toDelete), busy
+}
+
+// nodeShutdown evicts sessions of the node which is shutting down and creates
+// sessions instead of evicted idle ones on other nodes.
+func (c *client) nodeShutdown(ctx context.Context, address string) {
+	var (
+		evicted, marked, created int
+		err                      error
+	)
+	onDone := trace.TableOnPoolNodeShutdown(c.config.Trace().Compose(trace.ContextTable(ctx)), ctx, address)
+	defer func() {
+		onDone(evicted, marked, created, err)
+	}()
+
+	evicted, marked = c.recycleEndpoint(ctx, address)
+	for i := 0; i < evicted && !c.isClosed(); i++ {
+		var s Session
+		s, err = c.createSession(ctx)
+		if err != nil {
+			return
+		}
+		if s.Address() == address {
+			// Balancer chose the same node, replacement is useless.
+			closeCtx, cancel := context.WithTimeout(ctx, c.config.DeleteTimeout())
+			_ = s.Close(closeCtx)
+			cancel()
+			continue
+		}
+		if err = c.Put(ctx, s); err != nil {
+			return
+		}
+		created++
 	}
 }
 
@@ -630,7 +799,7 @@ func (c *client) keeper() {
 				// sessions are open than the lower limit of continuously kept sessions
 				if c.config.IdleKeepAliveThreshold() > 0 {
 					if keepAliveCount >= c.config.IdleKeepAliveThreshold() {
-						if c.config.KeepAliveMinSize() < lenIndex-len(toDelete) {
+						if c.keepAliveMinSize() < lenIndex-len(toDelete) {
 							toDelete = append(toDelete, s)
 							continue
 						}
@@ -639,6 +808,9 @@ func (c *client) keeper() {
 
 				err := c.keepAliveSession(context.Background(), s)
 				if err != nil {
+					c.mu.Lock()
+					c.keepAliveFailures++
+					c.mu.Unlock()
 					switch {
 					case
 						errors.IsOpError(err, errors.StatusBadSession),
@@ -650,7 +822,13 @@ func (c *client) keeper() {
 					continue
 				}
 
+				shutdown := s.IsShutdown()
 				c.mu.Lock()
+				if shutdown || c.index[s].mustRecycle(timeutil.Now()) {
+					c.mu.Unlock()
+					toDelete = append(toDelete, s)
+					continue
+				}
 				if !c.notify(s) {
 					// Need to push back build into list in order, to prevent
 					// shuffling of sessions order.
@@ -718,6 +896,112 @@ func (c *client) keeper() {
 	}
 }
 
+// keepAliveMinSize returns number of sessions which keeper must not delete.
+func (c *client) keepAliveMinSize() int {
+	if n := c.config.MinIdleSize(); n > c.config.KeepAliveMinSize() {
+		return n
+	}
+	return c.config.KeepAliveMinSize()
+}
+
+// warmUp creates sessions in background to keep at least MinIdleSize idle
+// sessions in the client.
+func (c *client) warmUp() {
+	defer close(c.warmUpDone)
+	for attempt := 0; ; {
+		select {
+		case <-c.warmUpStop:
+			return
+		case <-c.warmUpWake:
+		}
+		if err := c.fillIdle(); err == nil {
+			attempt = 0
+			continue
+		}
+		select {
+		case <-c.warmUpStop:
+			return
+		case <-retry.FastBackoff.Wait(attempt):
+			attempt++
+			c.wakeUpWarmUp()
+		}
+	}
+}
+
+// fillIdle creates sessions which are missing up to MinIdleSize and puts them
+// into the client.
+func (c *client) fillIdle() (err error) {
+	c.mu.Lock()
+	idle := c.idle.Len()
+	n := c.config.MinIdleSize() - idle
+	if free := c.limit - len(c.index) - c.createInProgress; n > free {
+		n = free
+	}
+	closed := c.closed
+	c.mu.Unlock()
+	if closed || n <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.warmUpStop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var (
+		created int
+		onDone  = trace.TableOnPoolWarmUp(c.config.Trace(), ctx, c.config.MinIdleSize(), idle)
+	)
+	defer func() {
+		c.mu.Lock()
+		idle := c.idle.Len()
+		c.mu.Unlock()
+		onDone(created, idle, err)
+	}()
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			s, e := c.createSession(ctx)
+			if e == nil {
+				e = c.Put(ctx, s)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case e == nil:
+				created++
+			case err == nil:
+				err = e
+			}
+		}()
+	}
+	wg.Wait()
+
+	return err
+}
+
+// wakeUpWarmUp signals warmUp() to check number of idle sessions.
+func (c *client) wakeUpWarmUp() {
+	if c.warmUpWake == nil {
+		return
+	}
+	select {
+	case c.warmUpWake <- struct{}{}:
+	default:
+	}
+}
+
 // getWaitCh returns pointer to a channel of sessions.
 //
 // Note that returning a pointer reduces allocations on sync.Pool usage –
@@ -813,7 +1097,7 @@ func (c *client) notify(s Session) (notified bool) {
 		// missed something and may want to retry (especially for case (3)).
 		//
 		// After that we taking a next waiter and repeat the same.
-		ch := c.waitq.Remove(el).(*chan Session)
+		ch := c.waitq.Remove(el).(*waiter).ch
 		select {
 		case *ch <- s:
 			// Case (1).
@@ -877,9 +1161,19 @@ func (c *client) takeIdle(s Session) (has, took bool) {
 	info = c.removeIdle(s)
 	info.keepAliveCount = 0
 	c.index[s] = info
+	c.use(s)
 	return
 }
 
+// use counts session uses, see config.MaxSessionUses().
+// p.mu must be held.
+func (c *client) use(s Session) {
+	if info, has := c.index[s]; has {
+		info.uses++
+		c.index[s] = info
+	}
+}
+
 // p.mu must be held.
 func (c *client) pushIdle(s Session, now time.Time) {
 	c.handlePushIdle(s, now, c.idle.PushBack(s))
@@ -947,18 +1241,34 @@ type sessionInfo struct {
 	idle           *list.Element
 	touched        time.Time
 	keepAliveCount int
+
+	uses    int       // Number of times session was got from the client.
+	maxUses int       // Zero if number of uses is not limited.
+	expires time.Time // Zero if lifetime is not limited.
+	recycle bool      // Set by RecycleEndpoint().
+}
+
+// newSessionInfo returns info of created session with randomly reduced limits
+// of lifetime and uses.
+func (c *client) newSessionInfo(now time.Time) (info sessionInfo) {
+	if age := c.config.MaxSessionAge(); age > 0 {
+		info.expires = now.Add(age - time.Duration(rand.Int63n(int64(age)/10+1)))
+	}
+	if uses := c.config.MaxSessionUses(); uses > 0 {
+		info.maxUses = uses - rand.Intn(uses/10+1)
+	}
+	return info
+}
+
+// mustRecycle reports whether session must be closed instead of becoming
+// idle.
+func (info sessionInfo) mustRecycle(now time.Time) bool {
+	return info.recycle ||
+		info.maxUses > 0 && info.uses >= info.maxUses ||
+		!info.expires.IsZero() && !now.Before(info.expires)
 }
 
 func panicLocked(mu sync.Locker, message string) {
 	mu.Unlock()
 	panic(message)
 }
-
-type poolStats struct {
-	Idle             int
-	Index            int
-	WaitQ            int
-	MinSize          int
-	MaxSize          int
-	CreateInProgress int
-}
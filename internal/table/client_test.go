@@ -1,6 +1,7 @@
 package table
 
 import (
+	"container/list"
 	"context"
 	"fmt"
 	"math/rand"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/cluster"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/errors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
 	"github.com/ydb-platform/ydb-go-sdk/v3/testutil/timeutil"
@@ -762,6 +764,382 @@ func TestSessionPoolGetPut(t *testing.T) {
 	assertCreated(2)
 }
 
+func TestSessionPoolWarmUp(t *testing.T) {
+	var (
+		created int32
+		warmUp  = make(chan trace.PoolWarmUpDoneInfo, 10)
+	)
+	p := newClientWithStubBuilder(
+		t,
+		testutil.NewCluster(
+			testutil.WithInvokeHandlers(
+				testutil.InvokeHandlers{
+					// nolint:unparam
+					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+						atomic.AddInt32(&created, 1)
+						return &Ydb_Table.CreateSessionResult{
+							SessionId: testutil.SessionID(),
+						}, nil
+					},
+					testutil.TableDeleteSession: okHandler,
+				},
+			),
+		),
+		0,
+		config.WithSizeLimit(5),
+		config.WithMinIdleSize(3),
+		config.WithIdleThreshold(-1),
+		config.WithTrace(trace.Table{
+			OnPoolWarmUp: func(trace.PoolWarmUpStartInfo) func(trace.PoolWarmUpDoneInfo) {
+				return func(info trace.PoolWarmUpDoneInfo) {
+					warmUp <- info
+				}
+			},
+		}),
+	)
+	defer mustClose(t, p)
+
+	mustWarmUp := func(created, idle int) {
+		t.Helper()
+		select {
+		case info := <-warmUp:
+			if info.Error != nil || info.Created != created || info.Idle != idle {
+				t.Fatalf("unexpected warm up result: %+v", info)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no warm up")
+		}
+	}
+	mustWarmUp(3, 3)
+
+	s1 := mustGetSession(t, p)
+	mustWarmUp(1, 3)
+	s2 := mustGetSession(t, p)
+	// Only one session may be created within SizeLimit.
+	mustWarmUp(1, 3)
+	if n := atomic.LoadInt32(&created); n != 5 {
+		t.Fatalf("unexpected number of created sessions: %d", n)
+	}
+
+	mustPutSession(t, p, s1)
+	_ = s2.Close(context.Background())
+	select {
+	case info := <-warmUp:
+		t.Fatalf("unexpected warm up: %+v", info)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if stats := p.Stats(); stats.Idle != 4 || stats.InUse != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestSessionPoolWaitQueue(t *testing.T) {
+	positions := make(chan int)
+	p := newClientWithStubBuilder(
+		t,
+		testutil.NewCluster(
+			testutil.WithInvokeHandlers(
+				testutil.InvokeHandlers{
+					// nolint:unparam
+					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+						return &Ydb_Table.CreateSessionResult{
+							SessionId: testutil.SessionID(),
+						}, nil
+					},
+					testutil.TableDeleteSession: okHandler,
+				},
+			),
+		),
+		1,
+		config.WithSizeLimit(1),
+		config.WithWaitQueueLimit(3),
+		config.WithIdleThreshold(-1),
+		config.WithTrace(trace.Table{
+			OnPoolWait: func(info trace.PoolWaitStartInfo) func(trace.PoolWaitDoneInfo) {
+				positions <- info.Position
+				return nil
+			},
+		}),
+	)
+	defer mustClose(t, p)
+
+	s := mustGetSession(t, p)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	for _, test := range []struct {
+		priority table.Priority
+		position int
+	}{
+		{table.PriorityBatch, 0},
+		{table.PriorityNormal, 0},
+		{table.PriorityInteractive, 0},
+	} {
+		go func(priority table.Priority) {
+			_, err := p.Get(table.WithPriority(ctx, priority))
+			errs <- err
+		}(test.priority)
+		if position := <-positions; position != test.position {
+			t.Fatalf("unexpected position of %d: %d; want %d", test.priority, position, test.position)
+		}
+	}
+
+	if _, err := p.Get(context.Background()); !errors.Is(err, ErrSessionPoolOverloaded) {
+		t.Fatalf("unexpected error: %v; want %v", err, ErrSessionPoolOverloaded)
+	}
+
+	p.mu.Lock()
+	var priorities []table.Priority
+	for el := p.waitq.Front(); el != nil; el = el.Next() {
+		priorities = append(priorities, el.Value.(*waiter).priority)
+	}
+	p.mu.Unlock()
+	exp := []table.Priority{table.PriorityInteractive, table.PriorityNormal, table.PriorityBatch}
+	if fmt.Sprint(priorities) != fmt.Sprint(exp) {
+		t.Fatalf("unexpected wait queue: %v; want %v", priorities, exp)
+	}
+
+	cancel()
+	for range exp {
+		if err := <-errs; err != context.Canceled {
+			t.Fatalf("unexpected error: %v; want %v", err, context.Canceled)
+		}
+	}
+	if stats := p.Stats(); stats.Waiting != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	mustPutSession(t, p, s)
+}
+
+func TestSessionPoolWaitWithoutTimeout(t *testing.T) {
+	var (
+		waits    int32
+		attempts = make(chan int, 1)
+	)
+	p := newClientWithStubBuilder(
+		t,
+		testutil.NewCluster(
+			testutil.WithInvokeHandlers(
+				testutil.InvokeHandlers{
+					// nolint:unparam
+					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+						return &Ydb_Table.CreateSessionResult{
+							SessionId: testutil.SessionID(),
+						}, nil
+					},
+					testutil.TableDeleteSession: okHandler,
+				},
+			),
+		),
+		1,
+		config.WithSizeLimit(1),
+		config.WithIdleThreshold(-1),
+		config.WithCreateSessionTimeout(time.Millisecond),
+		config.WithTrace(trace.Table{
+			OnPoolGet: func(trace.PoolGetStartInfo) func(trace.PoolGetDoneInfo) {
+				return func(info trace.PoolGetDoneInfo) {
+					if info.Error == nil {
+						attempts <- info.Attempts
+					}
+				}
+			},
+			OnPoolWait: func(trace.PoolWaitStartInfo) func(trace.PoolWaitDoneInfo) {
+				atomic.AddInt32(&waits, 1)
+				return nil
+			},
+		}),
+	)
+	defer mustClose(t, p)
+
+	s := mustGetSession(t, p)
+	<-attempts
+
+	wait := whenWantWaitCh(p)
+	got := make(chan Session)
+	go func() {
+		x, err := p.Get(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		got <- x
+	}()
+	<-wait
+	// Waiter stays in the queue much longer than CreateSessionTimeout.
+	time.Sleep(50 * time.Millisecond)
+	if stats := p.Stats(); stats.Waiting != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	mustPutSession(t, p, s)
+	if x := <-got; x != s {
+		t.Fatalf("unexpected session: %v; want %v", x, s)
+	}
+	if n := <-attempts; n != 1 {
+		t.Fatalf("unexpected attempts: %d; want 1", n)
+	}
+	if n := atomic.LoadInt32(&waits); n != 1 {
+		t.Fatalf("unexpected waits: %d; want 1", n)
+	}
+	mustPutSession(t, p, s)
+}
+
+func TestSessionPoolStats(t *testing.T) {
+	p := newClientWithStubBuilder(
+		t,
+		testutil.NewCluster(
+			testutil.WithInvokeHandlers(
+				testutil.InvokeHandlers{
+					// nolint:unparam
+					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+						return &Ydb_Table.CreateSessionResult{
+							SessionId: testutil.SessionID(),
+						}, nil
+					},
+					testutil.TableDeleteSession: okHandler,
+				},
+			),
+		),
+		3,
+		config.WithSizeLimit(3),
+		config.WithIdleThreshold(-1),
+	)
+	defer mustClose(t, p)
+
+	s1 := mustGetSession(t, p)
+	s2 := mustGetSession(t, p)
+	s3 := mustGetSession(t, p)
+	mustPutSession(t, p, s1)
+	_ = s2.Close(context.Background())
+	stats := p.Stats()
+	exp := table.Stats{
+		Idle:    1,
+		InUse:   1,
+		MinSize: p.config.KeepAliveMinSize(),
+		MaxSize: 3,
+		Created: 3,
+		Closed:  1,
+		Endpoints: map[string]table.EndpointStats{
+			s3.Address(): {Idle: 1, InUse: 1},
+		},
+	}
+	if fmt.Sprintf("%+v", stats) != fmt.Sprintf("%+v", exp) {
+		t.Fatalf("unexpected stats: %+v; want %+v", stats, exp)
+	}
+	mustPutSession(t, p, s3)
+}
+
+func TestSessionPoolRecycle(t *testing.T) {
+	shiftTime, cleanupNow := timeutil.StubTestHookTimeNow(time.Unix(0, 0))
+	defer cleanupNow()
+
+	newClient := func(t *testing.T, opts ...config.Option) *client {
+		return newClientWithStubBuilder(
+			t,
+			testutil.NewCluster(
+				testutil.WithInvokeHandlers(
+					testutil.InvokeHandlers{
+						// nolint:unparam
+						testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+							return &Ydb_Table.CreateSessionResult{
+								SessionId: testutil.SessionID(),
+							}, nil
+						},
+						testutil.TableDeleteSession: okHandler,
+					},
+				),
+			),
+			0,
+			append([]config.Option{
+				config.WithSizeLimit(3),
+				config.WithIdleThreshold(-1),
+			}, opts...)...,
+		)
+	}
+	mustStats := func(t *testing.T, p *client, idle, closed int) {
+		t.Helper()
+		if stats := p.Stats(); stats.Idle != idle || stats.Closed != uint64(closed) {
+			t.Fatalf("unexpected stats: %+v", stats)
+		}
+	}
+	t.Run("uses", func(t *testing.T) {
+		p := newClient(t, config.WithMaxSessionUses(2))
+		defer mustClose(t, p)
+
+		s := mustGetSession(t, p)
+		mustPutSession(t, p, s)
+		mustStats(t, p, 1, 0)
+		if x := mustGetSession(t, p); x != s {
+			t.Fatalf("unexpected session")
+		}
+		mustPutSession(t, p, s)
+		mustStats(t, p, 0, 1)
+		if !s.IsClosed() {
+			t.Fatalf("session is not closed")
+		}
+	})
+	t.Run("age", func(t *testing.T) {
+		p := newClient(t, config.WithMaxSessionAge(10*time.Second))
+		defer mustClose(t, p)
+
+		s := mustGetSession(t, p)
+		shiftTime(8 * time.Second)
+		mustPutSession(t, p, s)
+		mustStats(t, p, 1, 0)
+		_ = mustGetSession(t, p)
+		shiftTime(2 * time.Second)
+		mustPutSession(t, p, s)
+		mustStats(t, p, 0, 1)
+	})
+	t.Run("endpoint", func(t *testing.T) {
+		p := newClient(t)
+		defer mustClose(t, p)
+
+		s1 := mustGetSession(t, p)
+		s2 := mustGetSession(t, p)
+		mustPutSession(t, p, s1)
+		if n := p.RecycleEndpoint(context.Background(), "unknown"); n != 0 {
+			t.Fatalf("unexpected number of recycled sessions: %d", n)
+		}
+		if n := p.RecycleEndpoint(context.Background(), s1.Address()); n != 2 {
+			t.Fatalf("unexpected number of recycled sessions: %d", n)
+		}
+		mustStats(t, p, 0, 1)
+		mustPutSession(t, p, s2)
+		mustStats(t, p, 0, 2)
+		if !s1.IsClosed() || !s2.IsClosed() {
+			t.Fatalf("sessions are not closed")
+		}
+	})
+}
+
+func TestPushWaiter(t *testing.T) {
+	p := &client{
+		waitq: list.New(),
+	}
+	for _, w := range []struct {
+		waiter
+		position int
+	}{
+		{waiter{priority: table.PriorityNormal, seq: 2}, 0},
+		{waiter{priority: table.PriorityNormal, seq: 3}, 1},
+		{waiter{priority: table.PriorityBatch, seq: 4}, 2},
+		{waiter{priority: table.PriorityInteractive, seq: 5}, 0},
+		// Re-enqueued waiter keeps its place among waiters of the same priority.
+		{waiter{priority: table.PriorityNormal, seq: 1}, 1},
+	} {
+		w := w
+		if _, position := p.pushWaiter(&w.waiter); position != w.position {
+			t.Fatalf("unexpected position of %+v: %d; want %d", w.waiter, position, w.position)
+		}
+	}
+	var seqs []uint64
+	for el := p.waitq.Front(); el != nil; el = el.Next() {
+		seqs = append(seqs, el.Value.(*waiter).seq)
+	}
+	if exp := []uint64{5, 1, 2, 3, 4}; fmt.Sprint(seqs) != fmt.Sprint(exp) {
+		t.Fatalf("unexpected wait queue: %v; want %v", seqs, exp)
+	}
+}
+
 func TestSessionPoolDisableBackgroundGoroutines(t *testing.T) {
 	timer := timetest.StubSingleTimer(t)
 	defer timer.Cleanup()
@@ -1227,6 +1605,11 @@ func TestSessionPoolKeeperRetry(t *testing.T) {
 	if s2 == s1 {
 		t.Fatalf("retry build is not returned")
 	}
+	// Both keep-alive calls fail: the first one with deadline and the second
+	// one on unmarshaling of nil stub result.
+	if stats := p.Stats(); stats.KeepAliveFailures != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
 	mustPutSession(t, p, s1)
 	//keepalive success
 	shiftTime(p.config.IdleThreshold())
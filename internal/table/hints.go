@@ -0,0 +1,89 @@
+package table
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// metaServerHints is a trailer of responses with server hints.
+	metaServerHints = "x-ydb-server-hints"
+
+	// hintSessionClose means that server is going to close the session
+	// because its node is shutting down.
+	hintSessionClose = "session-close"
+)
+
+// sessionConn checks trailers of session requests for server hints.
+type sessionConn struct {
+	grpc.ClientConnInterface
+
+	s *session
+}
+
+func (c *sessionConn) Invoke(ctx context.Context, method string, req interface{}, res interface{}, opts ...grpc.CallOption) error {
+	var trailer metadata.MD
+	err := c.ClientConnInterface.Invoke(ctx, method, req, res, append(opts, grpc.Trailer(&trailer))...)
+	c.s.checkHints(ctx, trailer)
+	return err
+}
+
+func (c *sessionConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := c.ClientConnInterface.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionStream{
+		ClientStream: stream,
+		ctx:          ctx,
+		s:            c.s,
+	}, nil
+}
+
+// sessionStream checks trailers of session stream for server hints.
+type sessionStream struct {
+	grpc.ClientStream
+
+	ctx context.Context
+	s   *session
+}
+
+func (s *sessionStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		// Trailers are available when stream is done.
+		s.s.checkHints(s.ctx, s.ClientStream.Trailer())
+	}
+	return err
+}
+
+// checkHints marks session as shutdown and calls OnShutdown callbacks if
+// trailer contains hint of session close.
+func (s *session) checkHints(ctx context.Context, trailer metadata.MD) {
+	if !hasHint(trailer, hintSessionClose) {
+		return
+	}
+	s.mtx.Lock()
+	if s.flags&sessionShutdown != 0 {
+		s.mtx.Unlock()
+		return
+	}
+	s.flags |= sessionShutdown
+	callbacks := s.onShutdown
+	s.mtx.Unlock()
+
+	for _, cb := range callbacks {
+		cb(ctx)
+	}
+}
+
+func hasHint(trailer metadata.MD, hint string) bool {
+	for _, v := range trailer.Get(metaServerHints) {
+		if v == hint {
+			return true
+		}
+	}
+	return false
+}
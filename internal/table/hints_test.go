@@ -0,0 +1,134 @@
+package table
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+type trailerConn struct {
+	grpc.ClientConnInterface
+
+	trailer metadata.MD
+}
+
+func (c *trailerConn) Invoke(ctx context.Context, method string, req interface{}, res interface{}, opts ...grpc.CallOption) error {
+	for _, opt := range opts {
+		if t, ok := opt.(grpc.TrailerCallOption); ok {
+			*t.TrailerAddr = c.trailer
+		}
+	}
+	return nil
+}
+
+func TestSessionConnHints(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		trailer  metadata.MD
+		shutdown bool
+	}{
+		{
+			name: "no trailer",
+		},
+		{
+			name:    "other hint",
+			trailer: metadata.Pairs(metaServerHints, "other"),
+		},
+		{
+			name:     "session close",
+			trailer:  metadata.Pairs(metaServerHints, "other", metaServerHints, hintSessionClose),
+			shutdown: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				s      = &session{}
+				called int
+			)
+			s.OnShutdown(func(context.Context) {
+				called++
+			})
+			cc := &sessionConn{
+				ClientConnInterface: &trailerConn{trailer: test.trailer},
+				s:                   s,
+			}
+			for i := 0; i < 2; i++ {
+				if err := cc.Invoke(context.Background(), "method", nil, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if s.IsShutdown() != test.shutdown {
+				t.Fatalf("unexpected shutdown flag: %v", s.IsShutdown())
+			}
+			if exp := map[bool]int{true: 1}[test.shutdown]; called != exp {
+				t.Fatalf("unexpected number of callback calls: %d; want %d", called, exp)
+			}
+		})
+	}
+}
+
+func TestSessionPoolNodeShutdown(t *testing.T) {
+	done := make(chan trace.PoolNodeShutdownDoneInfo, 1)
+	p := newClientWithStubBuilder(
+		t,
+		testutil.NewCluster(
+			testutil.WithInvokeHandlers(
+				testutil.InvokeHandlers{
+					// nolint:unparam
+					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+						return &Ydb_Table.CreateSessionResult{
+							SessionId: testutil.SessionID(),
+						}, nil
+					},
+					testutil.TableDeleteSession: okHandler,
+				},
+			),
+		),
+		0,
+		config.WithSizeLimit(3),
+		config.WithIdleThreshold(-1),
+		config.WithTrace(trace.Table{
+			OnPoolNodeShutdown: func(trace.PoolNodeShutdownStartInfo) func(trace.PoolNodeShutdownDoneInfo) {
+				return func(info trace.PoolNodeShutdownDoneInfo) {
+					done <- info
+				}
+			},
+		}),
+	)
+	defer mustClose(t, p)
+
+	s1 := mustGetSession(t, p)
+	s2 := mustGetSession(t, p)
+	mustPutSession(t, p, s2)
+
+	s1.(*session).checkHints(context.Background(), metadata.Pairs(metaServerHints, hintSessionClose))
+	select {
+	case info := <-done:
+		// All stub sessions have the same address, so replacement is closed.
+		if info.Error != nil || info.Evicted != 1 || info.Marked != 1 || info.Created != 0 {
+			t.Fatalf("unexpected node shutdown result: %+v", info)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("no node shutdown")
+	}
+	if !s2.IsClosed() {
+		t.Fatalf("idle session is not closed")
+	}
+	mustPutSession(t, p, s1)
+	if !s1.IsClosed() {
+		t.Fatalf("shutdown session is not closed")
+	}
+	if stats := p.Stats(); stats.Idle != 0 || stats.InUse != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/resultset"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestWriteJSON(t *testing.T) {
+	newResult := func() *Result {
+		return NewResult(
+			NewResultSet(
+				WithColumns(
+					options.Column{Name: "id", Type: types.TypeUint64},
+					options.Column{Name: "title", Type: types.Optional(types.TypeUTF8)},
+				),
+				WithValues(
+					types.Uint64Value(1), types.OptionalValue(types.UTF8Value("a")),
+					types.Uint64Value(2), types.NullValue(types.TypeUTF8),
+				),
+			),
+		)
+	}
+	for _, test := range []struct {
+		name  string
+		write func(*bytes.Buffer, resultset.Result) error
+		exp   string
+	}{
+		{
+			name: "array",
+			write: func(buf *bytes.Buffer, res resultset.Result) error {
+				return resultset.WriteJSON(buf, res)
+			},
+			exp: `[{"id":1,"title":"a"},{"id":2,"title":null}]`,
+		},
+		{
+			name: "lines",
+			write: func(buf *bytes.Buffer, res resultset.Result) error {
+				return resultset.WriteJSONLines(buf, res)
+			},
+			exp: "{\"id\":1,\"title\":\"a\"}\n{\"id\":2,\"title\":null}\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			res := newResult()
+			if !res.NextResultSet(context.Background()) {
+				t.Fatal("no result set")
+			}
+			var buf bytes.Buffer
+			if err := test.write(&buf, res); err != nil {
+				t.Fatal(err)
+			}
+			if act := buf.String(); act != test.exp {
+				t.Errorf("unexpected json: %s, exp: %s", act, test.exp)
+			}
+		})
+	}
+}
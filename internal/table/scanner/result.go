@@ -2,6 +2,7 @@ package scanner
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -21,8 +22,9 @@ type Result struct {
 	SetChErr    *error
 	SetChCancel func()
 
-	nextSet int
-	closed  bool
+	statsMtx sync.Mutex
+	nextSet  int
+	closed   bool
 }
 
 var _ resultset.Result = &Result{}
@@ -45,8 +47,24 @@ func (r *Result) CurrentResultSet() resultset.ResultSet {
 	return r
 }
 
+// RawResultSet returns current result set as it was received from server.
+func (r *Result) RawResultSet() *Ydb.ResultSet {
+	return r.set
+}
+
+// AddQueryStats adds statistics of a part of streaming result to statistics
+// of the result. It may be called concurrently with Stats.
+func (r *Result) AddQueryStats(s *Ydb_TableStats.QueryStats) {
+	r.statsMtx.Lock()
+	defer r.statsMtx.Unlock()
+	r.QueryStats = mergeQueryStats(r.QueryStats, s)
+}
+
 // Stats returns query execution QueryStats.
+// Statistics of streaming result are aggregated over received parts.
 func (r *Result) Stats() stats.QueryStats {
+	r.statsMtx.Lock()
+	defer r.statsMtx.Unlock()
 	var s queryStats
 	s.stats = r.QueryStats
 	s.processCPUTime = time.Microsecond * time.Duration(r.QueryStats.GetProcessCpuTimeUs())
@@ -193,3 +193,112 @@ func NewResult(sets ...*Ydb.ResultSet) *Result {
 		Sets: sets,
 	}
 }
+
+func TestResultDecimal(t *testing.T) {
+	var (
+		nan, _ = types.ParseDecimal("nan", 22, 9)
+		x, _   = types.ParseDecimal("-1.5", 22, 9)
+	)
+	res := NewResult(
+		NewResultSet(
+			WithColumns(
+				options.Column{Name: "x", Type: types.DecimalType(22, 9)},
+				options.Column{Name: "y", Type: types.Optional(types.DecimalType(22, 9))},
+			),
+			WithValues(
+				types.DecimalValue(&x), types.OptionalValue(types.DecimalValue(&nan)),
+			),
+		),
+	)
+	if !res.NextResultSet(context.Background()) || !res.NextRow() {
+		t.Fatal("no rows")
+	}
+	var (
+		a types.Decimal
+		b *types.Decimal
+	)
+	if err := res.Scan(&a, &b); err != nil {
+		t.Fatal(err)
+	}
+	if a.Cmp(x) != 0 || a.String() != "-1.500000000" {
+		t.Errorf("unexpected decimal: %s", a)
+	}
+	if b == nil || !b.IsNaN() {
+		t.Errorf("unexpected decimal: %v", b)
+	}
+}
+
+type money struct {
+	units    int64
+	currency string
+}
+
+func (m money) MarshalYDB() (types.Value, error) {
+	return types.StructValue(
+		types.StructFieldValue("units", types.Int64Value(m.units)),
+		types.StructFieldValue("currency", types.UTF8Value(m.currency)),
+	), nil
+}
+
+func (m *money) UnmarshalYDB(raw types.RawValue) error {
+	return types.UnmarshalStruct(raw, func(name string) error {
+		switch name {
+		case "units":
+			m.units = raw.Int64()
+		case "currency":
+			m.currency = raw.UTF8()
+		default:
+			return fmt.Errorf("unexpected field %q", name)
+		}
+		return nil
+	})
+}
+
+type prices map[string][]money
+
+func (p *prices) UnmarshalYDB(raw types.RawValue) error {
+	*p = make(prices)
+	var key string
+	return types.UnmarshalDict(raw,
+		func(int) error {
+			key = raw.UTF8()
+			return nil
+		},
+		func(int) error {
+			return types.UnmarshalList(raw, func(int) error {
+				var m money
+				if err := m.UnmarshalYDB(raw); err != nil {
+					return err
+				}
+				(*p)[key] = append((*p)[key], m)
+				return nil
+			})
+		},
+	)
+}
+
+func TestResultUnmarshaler(t *testing.T) {
+	exp := prices{
+		"a": {{units: 1, currency: "USD"}, {units: 2, currency: "EUR"}},
+	}
+	v, err := types.Marshal(exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := NewResult(
+		NewResultSet(
+			WithColumns(options.Column{Name: "prices", Type: types.TypeOf(v)}),
+			WithValues(v),
+		),
+	)
+	if !res.NextResultSet(context.Background()) || !res.NextRow() {
+		t.Fatal("no rows")
+	}
+	var act prices
+	if err = res.Scan(&act); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(act, exp) {
+		t.Errorf("unexpected prices: %v, exp: %v", act, exp)
+	}
+}
@@ -37,6 +37,48 @@ func (s *queryStats) Compilation() (c *stats.CompilationStats) {
 	}
 }
 
+func (s *queryStats) QueryPlan() string {
+	if s == nil {
+		return ""
+	}
+	return s.stats.GetQueryPlan()
+}
+
+func (s *queryStats) QueryAST() string {
+	if s == nil {
+		return ""
+	}
+	return s.stats.GetQueryAst()
+}
+
+// mergeQueryStats returns statistics of streaming result with statistics of
+// its next part. Phases of parts are concatenated, CPU time is summed up and
+// compilation, plan and AST are taken from the parts which contain them.
+func mergeQueryStats(acc, part *Ydb_TableStats.QueryStats) *Ydb_TableStats.QueryStats {
+	if acc == nil {
+		return part
+	}
+	x := &Ydb_TableStats.QueryStats{
+		QueryPhases:      make([]*Ydb_TableStats.QueryPhaseStats, 0, len(acc.QueryPhases)+len(part.QueryPhases)),
+		Compilation:      acc.Compilation,
+		ProcessCpuTimeUs: acc.ProcessCpuTimeUs + part.ProcessCpuTimeUs,
+		QueryPlan:        acc.QueryPlan,
+		QueryAst:         acc.QueryAst,
+	}
+	x.QueryPhases = append(x.QueryPhases, acc.QueryPhases...)
+	x.QueryPhases = append(x.QueryPhases, part.QueryPhases...)
+	if part.Compilation != nil {
+		x.Compilation = part.Compilation
+	}
+	if part.QueryPlan != "" {
+		x.QueryPlan = part.QueryPlan
+	}
+	if part.QueryAst != "" {
+		x.QueryAst = part.QueryAst
+	}
+	return x
+}
+
 // NextPhase returns next execution phase within query.
 // If ok flag is false, then there are no more phases and p is invalid.
 func (s *queryStats) NextPhase() (p stats.QueryPhase, ok bool) {
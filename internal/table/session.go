@@ -11,6 +11,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/cluster"
@@ -20,10 +21,13 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/operation"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	public "github.com/ydb-platform/ydb-go-sdk/v3/operation"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/resultset"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil/timeutil"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
@@ -31,12 +35,18 @@ var (
 	ErrNilConnection = errors.New("build with nil connection")
 )
 
+const (
+	alterTableMethod = "/Ydb.Table.V1.TableService/AlterTable"
+	copyTableMethod  = "/Ydb.Table.V1.TableService/CopyTable"
+)
+
 type sessionFlags int
 
 const (
 	sessionClosed = sessionFlags(1 << iota)
 	sessionInPool
 	sessionInFlight
+	sessionShutdown
 )
 
 // session represents a single table API session.
@@ -49,12 +59,14 @@ const (
 type session struct {
 	id           string
 	endpoint     cluster.Endpoint
+	cc           grpc.ClientConnInterface
 	tableService Ydb_Table_V1.TableServiceClient
 	trace        trace.Table
 	mtx          sync.Mutex
 	flags        sessionFlags
 	status       options.SessionStatus
 	onClose      []func(ctx context.Context)
+	onShutdown   []func(ctx context.Context)
 }
 
 func (s *session) Status() string {
@@ -119,13 +131,17 @@ func newSession(ctx context.Context, cc grpc.ClientConnInterface, t trace.Table)
 	if err != nil {
 		return nil, err
 	}
-	s = &session{
-		id:           result.GetSessionId(),
-		endpoint:     info,
-		tableService: Ydb_Table_V1.NewTableServiceClient(info),
-		trace:        t,
+	x := &session{
+		id:       result.GetSessionId(),
+		endpoint: info,
+		trace:    t,
 	}
-	return
+	x.cc = &sessionConn{
+		ClientConnInterface: info,
+		s:                   x,
+	}
+	x.tableService = Ydb_Table_V1.NewTableServiceClient(x.cc)
+	return x, nil
 }
 
 func (s *session) ID() string {
@@ -135,6 +151,14 @@ func (s *session) ID() string {
 	return s.id
 }
 
+// Address returns address of the endpoint session is bound to.
+func (s *session) Address() string {
+	if s.endpoint == nil {
+		return ""
+	}
+	return s.endpoint.Address()
+}
+
 func (s *session) OnClose(cb func(ctx context.Context)) {
 	if s.IsClosed() {
 		return
@@ -144,6 +168,25 @@ func (s *session) OnClose(cb func(ctx context.Context)) {
 	s.onClose = append(s.onClose, cb)
 }
 
+// OnShutdown registers callback called once when server hints that node of
+// the session is shutting down.
+func (s *session) OnShutdown(cb func(ctx context.Context)) {
+	if s.IsClosed() {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.onShutdown = append(s.onShutdown, cb)
+}
+
+// IsShutdown reports whether server hinted that node of the session is
+// shutting down.
+func (s *session) IsShutdown() bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.flags&sessionShutdown != 0
+}
+
 func (s *session) Close(ctx context.Context) (err error) {
 	if s.IsClosed() {
 		return nil
@@ -300,11 +343,7 @@ func (s *session) DescribeTable(ctx context.Context, path string, opts ...option
 
 	indexes := make([]options.IndexDescription, len(result.Indexes))
 	for i, idx := range result.GetIndexes() {
-		indexes[i] = options.IndexDescription{
-			Name:         idx.GetName(),
-			IndexColumns: idx.GetIndexColumns(),
-			Status:       idx.GetStatus(),
-		}
+		indexes[i] = options.NewIndexDescription(idx)
 	}
 
 	return options.Description{
@@ -364,6 +403,53 @@ func (s *session) CopyTable(ctx context.Context, dst, src string, opts ...option
 	return err
 }
 
+// AlterTableAsync starts modification of table schema in async operation mode
+// and returns handle of started operation.
+func (s *session) AlterTableAsync(ctx context.Context, path string, opts ...options.AlterTableOption) (op public.Operation, err error) {
+	request := Ydb_Table.AlterTableRequest{
+		SessionId: s.id,
+		Path:      path,
+	}
+	for _, opt := range opts {
+		opt((*options.AlterTableDesc)(&request))
+	}
+	var response Ydb_Table.AlterTableResponse
+	err = s.cc.Invoke(
+		cluster.WithEndpoint(operation.WithMode(ctx, operation.ModeAsync), s.endpoint),
+		alterTableMethod, &request, &response,
+	)
+	return asyncOperation(response.GetOperation(), err)
+}
+
+// CopyTableAsync starts copying of table in async operation mode and returns
+// handle of started operation.
+func (s *session) CopyTableAsync(ctx context.Context, dst, src string, opts ...options.CopyTableOption) (op public.Operation, err error) {
+	request := Ydb_Table.CopyTableRequest{
+		SessionId:       s.id,
+		SourcePath:      src,
+		DestinationPath: dst,
+	}
+	for _, opt := range opts {
+		opt((*options.CopyTableDesc)(&request))
+	}
+	var response Ydb_Table.CopyTableResponse
+	err = s.cc.Invoke(
+		cluster.WithEndpoint(operation.WithMode(ctx, operation.ModeAsync), s.endpoint),
+		copyTableMethod, &request, &response,
+	)
+	return asyncOperation(response.GetOperation(), err)
+}
+
+// asyncOperation makes operation handle from response of call in async mode.
+// Not ready operation is reported by transport layer as an error, but it is
+// a regular result of such call.
+func asyncOperation(op *Ydb_Operations.Operation, err error) (public.Operation, error) {
+	if err != nil && !errors.Is(err, errors.ErrOperationNotReady) {
+		return public.Operation{}, err
+	}
+	return public.New(op), nil
+}
+
 // Explain explains data query represented by text.
 func (s *session) Explain(ctx context.Context, query string) (exp table.DataQueryExplanation, err error) {
 	var (
@@ -390,6 +476,31 @@ func (s *session) Explain(ctx context.Context, query string) (exp table.DataQuer
 	}, nil
 }
 
+// ExplainScanQuery explains scan query represented by text.
+func (s *session) ExplainScanQuery(ctx context.Context, query string) (exp table.DataQueryExplanation, err error) {
+	res, err := s.StreamExecuteScanQuery(ctx, query, nil,
+		options.WithExecuteScanQueryMode(options.ExecuteScanQueryRequestModeExplain),
+	)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = res.Close()
+	}()
+	// Plan is sent within statistics of the stream, so the stream is drained.
+	for res.NextResultSet(ctx) {
+		continue
+	}
+	if err = res.Err(); err != nil {
+		return
+	}
+	stats := res.Stats()
+	return table.DataQueryExplanation{
+		AST:  stats.QueryAST(),
+		Plan: stats.QueryPlan(),
+	}, nil
+}
+
 // Statement is a prepared statement. Like a single build, it is not safe for
 // concurrent use by multiple goroutines.
 type Statement struct {
@@ -421,11 +532,16 @@ func (s *Statement) execute(
 ) (
 	txr table.Transaction, r resultset.Result, err error,
 ) {
+	x, err := activeTx(tx)
+	if err != nil {
+		return nil, nil, err
+	}
 	_, res, err := s.session.executeDataQuery(ctx, tx, s.query, params, opts...)
+	txr = s.session.trackTx(ctx, tx, x, res, err)
 	if err != nil {
 		return nil, nil, err
 	}
-	return s.session.executeQueryResult(res)
+	return txr, s.session.executeQueryResult(res), nil
 }
 
 func (s *Statement) NumInput() int {
@@ -492,7 +608,12 @@ func (s *session) Execute(
 		onDone(true, r, err)
 	}()
 
+	x, err := activeTx(tx)
+	if err != nil {
+		return nil, nil, err
+	}
 	request, result, err := s.executeDataQuery(ctx, tx, q, params, opts...)
+	txr = s.trackTx(ctx, tx, x, result, err)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -506,7 +627,7 @@ func (s *session) Execute(
 		subq.initPrepared(queryID)
 	}
 
-	return s.executeQueryResult(result)
+	return txr, s.executeQueryResult(result), nil
 }
 
 func keepInCache(req *Ydb_Table.ExecuteDataQueryRequest) bool {
@@ -514,18 +635,79 @@ func keepInCache(req *Ydb_Table.ExecuteDataQueryRequest) bool {
 	return p != nil && p.KeepInCache
 }
 
-// executeQueryResult returns Transaction and Result built from received
-// result.
-func (s *session) executeQueryResult(res *Ydb_Table.ExecuteQueryResult) (table.Transaction, resultset.Result, error) {
-	t := &Transaction{
-		id: res.GetTxMeta().GetId(),
-		s:  s,
-	}
-	r := &scanner.Result{
+// executeQueryResult returns Result built from received result.
+func (s *session) executeQueryResult(res *Ydb_Table.ExecuteQueryResult) resultset.Result {
+	return &scanner.Result{
 		Sets:       res.GetResultSets(),
 		QueryStats: res.GetQueryStats(),
 	}
-	return t, r, nil
+}
+
+// activeTx returns transaction of tx control set with table.WithTx or nil.
+// It returns table.ErrTransactionFinished if the transaction is not active.
+func activeTx(tx *table.TransactionControl) (*Transaction, error) {
+	x, _ := tx.Tx().(*Transaction)
+	if x != nil && !x.IsActive() {
+		return nil, table.ErrTransactionFinished
+	}
+	return x, nil
+}
+
+// trackTx updates state of transaction x after query execution and returns
+// transaction of the query. If x is nil and query is executed with
+// table.BeginTx transaction control, new transaction is begun.
+// Transaction is finished if it is committed by the query or invalidated by
+// error of the query.
+func (s *session) trackTx(
+	ctx context.Context, tx *table.TransactionControl, x *Transaction,
+	res *Ydb_Table.ExecuteQueryResult, err error,
+) table.Transaction {
+	commit := tx.Desc().GetCommitTx()
+	if x == nil {
+		if err != nil {
+			return nil
+		}
+		x = &Transaction{
+			id: res.GetTxMeta().GetId(),
+			s:  s,
+		}
+		if settings := tx.Desc().GetBeginTx(); settings != nil {
+			x.settings = new(table.TransactionSettings)
+			proto.Merge(x.settings.Settings(), settings)
+			x.begin(ctx, true)
+		} else {
+			// Transaction is not tracked, e.g. it is continued by ID.
+			if commit {
+				x.state = txStateCommitted
+			}
+			return x
+		}
+	}
+	x.queries++
+	switch {
+	case commit && isServerOutcome(err):
+		x.finish(txStateCommitted, err)
+	case err != nil && txInvalidated(err):
+		x.finish(txStateRolledBack, err)
+	}
+	return x
+}
+
+// txInvalidated reports whether err means that transaction cannot be
+// continued: it is aborted or lost by server or the session must be deleted.
+func txInvalidated(err error) bool {
+	if retry.Check(err).MustDeleteSession() {
+		return true
+	}
+	return errors.IsOpError(err, errors.StatusAborted) || errors.IsOpError(err, errors.StatusNotFound)
+}
+
+// isServerOutcome reports whether err is a result of operation completed by
+// server, unlike transport errors or cancellation which leave outcome of the
+// operation unknown.
+func isServerOutcome(err error) bool {
+	var op *errors.OpError
+	return err == nil || errors.As(err, &op)
 }
 
 // executeDataQuery executes data query.
@@ -808,7 +990,7 @@ func (s *session) StreamExecuteScanQuery(ctx context.Context, query string, para
 						r.SetCh <- resultSet
 					}
 					if stats := result.GetQueryStats(); stats != nil {
-						r.QueryStats = stats
+						r.AddQueryStats(stats)
 					}
 				}
 			}
@@ -851,25 +1033,70 @@ func (s *session) BeginTransaction(ctx context.Context, tx *table.TransactionSet
 	if err != nil {
 		return
 	}
-	return &Transaction{
-		id: result.GetTxMeta().GetId(),
-		s:  s,
-	}, nil
+	t := &Transaction{
+		id:       result.GetTxMeta().GetId(),
+		s:        s,
+		settings: tx,
+	}
+	t.begin(ctx, false)
+	return t, nil
 }
 
+type txState int
+
+const (
+	txStateActive txState = iota
+	txStateCommitted
+	txStateRolledBack
+)
+
 // Transaction is a database transaction.
 // Hence build methods are not goroutine safe, Transaction is not goroutine
 // safe either.
 type Transaction struct {
-	id string
-	s  *session
-	c  *table.TransactionControl
+	id       string
+	s        *session
+	c        *table.TransactionControl
+	settings *table.TransactionSettings
+
+	state   txState
+	queries int
+	started time.Time
+	onDone  func(duration time.Duration, queries int, committed bool, _ error)
+}
+
+// begin starts tracing of transaction.
+func (tx *Transaction) begin(ctx context.Context, lazy bool) {
+	tx.started = timeutil.Now()
+	tx.onDone = trace.TableOnSessionTransaction(tx.s.trace, ctx, tx.s, tx, tx.settings.Mode(), lazy)
+}
+
+// finish sets final state of transaction and finishes its tracing.
+// Error err is an outcome of the last operation of transaction.
+func (tx *Transaction) finish(state txState, err error) {
+	tx.state = state
+	if tx.onDone != nil {
+		tx.onDone(timeutil.Now().Sub(tx.started), tx.queries, state == txStateCommitted && err == nil, err)
+		tx.onDone = nil
+	}
 }
 
 func (tx *Transaction) ID() string {
 	return tx.id
 }
 
+func (tx *Transaction) Session() table.Session {
+	return tx.s
+}
+
+func (tx *Transaction) Settings() *table.TransactionSettings {
+	return tx.settings
+}
+
+func (tx *Transaction) IsActive() bool {
+	return tx.state == txStateActive
+}
+
 func (tx *Transaction) IsNil() bool {
 	return tx == nil
 }
@@ -896,9 +1123,16 @@ func (tx *Transaction) ExecuteStatement(
 
 // CommitTx commits specified active transaction.
 func (tx *Transaction) CommitTx(ctx context.Context, opts ...options.CommitTransactionOption) (r resultset.Result, err error) {
+	if !tx.IsActive() {
+		return nil, table.ErrTransactionFinished
+	}
 	onDone := trace.TableOnSessionTransactionCommit(tx.s.trace, ctx, tx.s, tx)
 	defer func() {
 		onDone(err)
+		// Transaction stays active if outcome of commit is unknown.
+		if isServerOutcome(err) {
+			tx.finish(txStateCommitted, err)
+		}
 	}()
 	var (
 		request = &Ydb_Table.CommitTransactionRequest{
@@ -927,9 +1161,15 @@ func (tx *Transaction) CommitTx(ctx context.Context, opts ...options.CommitTrans
 
 // Rollback performs a rollback of the specified active transaction.
 func (tx *Transaction) Rollback(ctx context.Context) (err error) {
+	if !tx.IsActive() {
+		return table.ErrTransactionFinished
+	}
 	onDone := trace.TableOnSessionTransactionRollback(tx.s.trace, ctx, tx.s, tx)
 	defer func() {
 		onDone(err)
+		if isServerOutcome(err) {
+			tx.finish(txStateRolledBack, err)
+		}
 	}()
 	if m, _ := operation.ContextMode(ctx); m == operation.ModeUnknown {
 		ctx = operation.WithMode(ctx, operation.ModeSync)
@@ -3,18 +3,23 @@ package table
 import (
 	"context"
 	"errors"
+	"io"
 	"reflect"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Scheme"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/cmp"
+	ydbErrors "github.com/ydb-platform/ydb-go-sdk/v3/internal/errors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/operation"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
@@ -22,6 +27,7 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
 func TestSessionKeepAlive(t *testing.T) {
@@ -399,3 +405,394 @@ func TestSessionOperationModeOnExecuteDataQuery(t *testing.T) {
 		)
 	}
 }
+
+func TestSessionTransaction(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		txs  = make(chan trace.SessionTransactionStartInfo, 10)
+		done = make(chan trace.SessionTransactionDoneInfo, 10)
+
+		// Errors of the next query and commit.
+		queryErr  error
+		commitErr error
+	)
+	b := StubBuilder{
+		T: t,
+		Cluster: testutil.NewCluster(
+			testutil.WithInvokeHandlers(
+				testutil.InvokeHandlers{
+					// nolint:unparam
+					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+						return &Ydb_Table.CreateSessionResult{
+							SessionId: testutil.SessionID(),
+						}, nil
+					},
+					// nolint:unparam
+					testutil.TableBeginTransaction: func(interface{}) (proto.Message, error) {
+						return &Ydb_Table.BeginTransactionResult{
+							TxMeta: &Ydb_Table.TransactionMeta{
+								Id: "begun",
+							},
+						}, nil
+					},
+					// nolint:unparam
+					testutil.TableExecuteDataQuery: func(request interface{}) (proto.Message, error) {
+						if err := queryErr; err != nil {
+							queryErr = nil
+							return nil, err
+						}
+						r := request.(*Ydb_Table.ExecuteDataQueryRequest)
+						id := r.GetTxControl().GetTxId()
+						if r.GetTxControl().GetBeginTx() != nil && !r.GetTxControl().GetCommitTx() {
+							id = "lazy"
+						}
+						return &Ydb_Table.ExecuteQueryResult{
+							TxMeta: &Ydb_Table.TransactionMeta{
+								Id: id,
+							},
+						}, nil
+					},
+					// nolint:unparam
+					testutil.TableCommitTransaction: func(interface{}) (proto.Message, error) {
+						if err := commitErr; err != nil {
+							commitErr = nil
+							return nil, err
+						}
+						return &Ydb_Table.CommitTransactionResult{}, nil
+					},
+					// nolint:unparam
+					testutil.TableRollbackTransaction: func(interface{}) (proto.Message, error) {
+						return &Ydb_Table.CommitTransactionResult{}, nil
+					},
+				},
+			),
+		),
+	}
+	s, err := b.createSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.(*session).trace = trace.Table{
+		OnSessionTransaction: func(info trace.SessionTransactionStartInfo) func(trace.SessionTransactionDoneInfo) {
+			txs <- info
+			return func(info trace.SessionTransactionDoneInfo) {
+				done <- info
+			}
+		},
+	}
+	mustTx := func(id, mode string, lazy bool) {
+		t.Helper()
+		select {
+		case info := <-txs:
+			if info.Tx.ID() != id || info.Mode != mode || info.Lazy != lazy {
+				t.Fatalf("unexpected transaction start: %+v", info)
+			}
+		default:
+			t.Fatalf("no transaction start")
+		}
+	}
+	mustDoneWithError := func(queries int, committed bool, err error) {
+		t.Helper()
+		select {
+		case info := <-done:
+			if info.Queries != queries || info.Committed != committed || info.Error != err {
+				t.Fatalf("unexpected transaction done: %+v", info)
+			}
+		default:
+			t.Fatalf("no transaction done")
+		}
+	}
+	mustDone := func(queries int, committed bool) {
+		t.Helper()
+		mustDoneWithError(queries, committed, nil)
+	}
+	mustNotDone := func() {
+		t.Helper()
+		select {
+		case info := <-done:
+			t.Fatalf("unexpected transaction done: %+v", info)
+		default:
+		}
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		settings := table.TxSettings(table.WithSerializableReadWrite())
+		tx, err := s.BeginTransaction(ctx, settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustTx("begun", table.TxModeSerializableReadWrite, false)
+		if tx.Session() != s || tx.Settings() != settings || !tx.IsActive() {
+			t.Fatalf("unexpected transaction: %+v", tx)
+		}
+		if _, err = tx.Execute(ctx, "SELECT 1", nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tx.CommitTx(ctx); err != nil {
+			t.Fatal(err)
+		}
+		mustDone(1, true)
+		if tx.IsActive() {
+			t.Fatalf("committed transaction is active")
+		}
+		if _, err = tx.Execute(ctx, "SELECT 1", nil); !errors.Is(err, table.ErrTransactionFinished) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = tx.Rollback(ctx); !errors.Is(err, table.ErrTransactionFinished) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("rollback", func(t *testing.T) {
+		tx, err := s.BeginTransaction(ctx, table.TxSettings())
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustTx("begun", table.TxModeUnknown, false)
+		if err = tx.Rollback(ctx); err != nil {
+			t.Fatal(err)
+		}
+		mustDone(0, false)
+		if _, err = tx.CommitTx(ctx); !errors.Is(err, table.ErrTransactionFinished) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("query error", func(t *testing.T) {
+		tx, err := s.BeginTransaction(ctx, table.TxSettings(table.WithSerializableReadWrite()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustTx("begun", table.TxModeSerializableReadWrite, false)
+		// Error which does not invalidate transaction.
+		queryErr = ydbErrors.NewOpError(ydbErrors.WithOEReason(ydbErrors.StatusPreconditionFailed))
+		if _, err = tx.Execute(ctx, "SELECT 1", nil); err == nil {
+			t.Fatal("expected error")
+		}
+		mustNotDone()
+		if !tx.IsActive() {
+			t.Fatalf("transaction is finished by precondition failure")
+		}
+		aborted := ydbErrors.NewOpError(ydbErrors.WithOEReason(ydbErrors.StatusAborted))
+		queryErr = aborted
+		if _, err = tx.Execute(ctx, "SELECT 2", nil); !errors.Is(err, aborted) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustDoneWithError(2, false, aborted)
+		if tx.IsActive() {
+			t.Fatalf("aborted transaction is active")
+		}
+	})
+	t.Run("commit error", func(t *testing.T) {
+		tx, err := s.BeginTransaction(ctx, table.TxSettings(table.WithSerializableReadWrite()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustTx("begun", table.TxModeSerializableReadWrite, false)
+		// Outcome of commit is unknown on transport error.
+		commitErr = ydbErrors.NewTransportError(ydbErrors.WithTEReason(ydbErrors.TransportErrorUnavailable))
+		if _, err = tx.CommitTx(ctx); err == nil {
+			t.Fatal("expected error")
+		}
+		mustNotDone()
+		if !tx.IsActive() {
+			t.Fatalf("transaction is finished by transport error")
+		}
+		aborted := ydbErrors.NewOpError(ydbErrors.WithOEReason(ydbErrors.StatusAborted))
+		commitErr = aborted
+		if _, err = tx.CommitTx(ctx); !errors.Is(err, aborted) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustDoneWithError(0, false, aborted)
+		if tx.IsActive() {
+			t.Fatalf("aborted transaction is active")
+		}
+	})
+	t.Run("lazy", func(t *testing.T) {
+		tx, _, err := s.Execute(ctx, table.TxControl(table.BeginTx(table.WithOnlineReadOnly())), "SELECT 1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustTx("lazy", table.TxModeOnlineReadOnly, true)
+		if tx.Settings().Settings().GetOnlineReadOnly() == nil || !tx.IsActive() {
+			t.Fatalf("unexpected transaction: %+v", tx)
+		}
+		x, _, err := s.Execute(ctx, table.TxControl(table.WithTx(tx), table.CommitTx()), "SELECT 2", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x != tx || tx.IsActive() {
+			t.Fatalf("transaction is not committed by query")
+		}
+		mustDone(2, true)
+		if _, _, err = s.Execute(ctx, table.TxControl(table.WithTx(tx)), "SELECT 3", nil); !errors.Is(err, table.ErrTransactionFinished) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("lazy commit", func(t *testing.T) {
+		tx, _, err := s.Execute(ctx, table.TxControl(table.BeginTx(table.WithStaleReadOnly()), table.CommitTx()), "SELECT 1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustTx("", table.TxModeStaleReadOnly, true)
+		mustDone(1, true)
+		if tx.IsActive() {
+			t.Fatalf("committed transaction is active")
+		}
+	})
+}
+
+// scanQueryStream is a client stream of scan query partial responses.
+type scanQueryStream struct {
+	grpc.ClientStream
+
+	requests chan<- *Ydb_Table.ExecuteScanQueryRequest
+	parts    []*Ydb_Table.ExecuteScanQueryPartialResponse
+}
+
+func (s *scanQueryStream) SendMsg(m interface{}) error {
+	s.requests <- m.(*Ydb_Table.ExecuteScanQueryRequest)
+	return nil
+}
+
+func (s *scanQueryStream) CloseSend() error { return nil }
+
+func (s *scanQueryStream) Trailer() metadata.MD { return nil }
+
+func (s *scanQueryStream) RecvMsg(m interface{}) error {
+	if len(s.parts) == 0 {
+		return io.EOF
+	}
+	proto.Reset(m.(proto.Message))
+	proto.Merge(m.(proto.Message), s.parts[0])
+	s.parts = s.parts[1:]
+	return nil
+}
+
+func scanQueryStubBuilder(t *testing.T, requests chan<- *Ydb_Table.ExecuteScanQueryRequest, parts ...*Ydb_Table.ExecuteScanQueryPartialResult) StubBuilder {
+	return StubBuilder{
+		T: t,
+		Cluster: testutil.NewCluster(
+			testutil.WithInvokeHandlers(
+				testutil.InvokeHandlers{
+					// nolint:unparam
+					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+						return &Ydb_Table.CreateSessionResult{
+							SessionId: testutil.SessionID(),
+						}, nil
+					},
+				},
+			),
+			testutil.WithNewStreamHandlers(
+				testutil.NewStreamHandlers{
+					// nolint:unparam
+					testutil.TableStreamExecuteScanQuery: func(*grpc.StreamDesc) (grpc.ClientStream, error) {
+						s := &scanQueryStream{requests: requests}
+						for _, part := range parts {
+							s.parts = append(s.parts, &Ydb_Table.ExecuteScanQueryPartialResponse{
+								Result: part,
+							})
+						}
+						return s, nil
+					},
+				},
+			),
+		),
+	}
+}
+
+func TestSessionStreamExecuteScanQueryStats(t *testing.T) {
+	ctx := context.Background()
+	requests := make(chan *Ydb_Table.ExecuteScanQueryRequest, 1)
+	b := scanQueryStubBuilder(t, requests,
+		&Ydb_Table.ExecuteScanQueryPartialResult{
+			ResultSet: &Ydb.ResultSet{},
+			QueryStats: &Ydb_TableStats.QueryStats{
+				QueryPhases: []*Ydb_TableStats.QueryPhaseStats{
+					{DurationUs: 1},
+				},
+				Compilation: &Ydb_TableStats.CompilationStats{
+					DurationUs: 3,
+				},
+				ProcessCpuTimeUs: 10,
+			},
+		},
+		&Ydb_Table.ExecuteScanQueryPartialResult{
+			ResultSet: &Ydb.ResultSet{},
+		},
+		&Ydb_Table.ExecuteScanQueryPartialResult{
+			QueryStats: &Ydb_TableStats.QueryStats{
+				QueryPhases: []*Ydb_TableStats.QueryPhaseStats{
+					{DurationUs: 2},
+				},
+				ProcessCpuTimeUs: 20,
+			},
+		},
+	)
+	s, err := b.createSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.StreamExecuteScanQuery(ctx, "SELECT 1", nil,
+		options.WithExecuteScanQueryCollectStatsModeBasic(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := <-requests; r.GetCollectStats() != Ydb_Table.QueryStatsCollection_STATS_COLLECTION_BASIC {
+		t.Fatalf("unexpected collect stats mode: %v", r.GetCollectStats())
+	}
+	sets := 0
+	for res.NextResultSet(ctx) {
+		sets++
+	}
+	if err = res.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if sets != 2 {
+		t.Fatalf("unexpected number of result sets: %d", sets)
+	}
+	stats := res.Stats()
+	if stats.ProcessCPUTime() != 30*time.Microsecond {
+		t.Fatalf("unexpected process CPU time: %v", stats.ProcessCPUTime())
+	}
+	if c := stats.Compilation(); c == nil || c.Duration != 3*time.Microsecond {
+		t.Fatalf("unexpected compilation: %+v", c)
+	}
+	var durations []time.Duration
+	for {
+		phase, ok := stats.NextPhase()
+		if !ok {
+			break
+		}
+		durations = append(durations, phase.Duration())
+	}
+	if !reflect.DeepEqual(durations, []time.Duration{time.Microsecond, 2 * time.Microsecond}) {
+		t.Fatalf("unexpected phases: %v", durations)
+	}
+}
+
+func TestSessionExplainScanQuery(t *testing.T) {
+	ctx := context.Background()
+	requests := make(chan *Ydb_Table.ExecuteScanQueryRequest, 1)
+	b := scanQueryStubBuilder(t, requests,
+		&Ydb_Table.ExecuteScanQueryPartialResult{
+			QueryStats: &Ydb_TableStats.QueryStats{
+				QueryPlan: "plan",
+				QueryAst:  "ast",
+			},
+		},
+	)
+	s, err := b.createSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp, err := s.ExplainScanQuery(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := <-requests; r.GetMode() != Ydb_Table.ExecuteScanQueryRequest_MODE_EXPLAIN {
+		t.Fatalf("unexpected mode: %v", r.GetMode())
+	}
+	if exp != (table.DataQueryExplanation{AST: "ast", Plan: "plan"}) {
+		t.Fatalf("unexpected explanation: %+v", exp)
+	}
+}
@@ -0,0 +1,123 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TypeOf(v V) T {
+	return v.(Value).t
+}
+
+// Primitive returns primitive value stored in v as PrimitiveFromYDB does.
+func Primitive(v V) interface{} {
+	return PrimitiveFromYDB(v.(Value).v)
+}
+
+// Decimal returns decimal value stored in v as big-endian 128 bit signed integer.
+func Decimal(v V) (DecimalType, [16]byte, error) {
+	x := v.(Value)
+	t, ok := x.t.(DecimalType)
+	if !ok {
+		return t, [16]byte{}, typeError(x.t, "Decimal")
+	}
+	return t, BigEndianUint128(x.v.GetHigh_128(), x.v.GetLow_128()), nil
+}
+
+// OptionalItem returns underlying value of Optional value v.
+// It returns false if v is NULL.
+func OptionalItem(v V) (item V, ok bool, err error) {
+	x := v.(Value)
+	t, isOptional := x.t.(OptionalType)
+	if !isOptional {
+		return nil, false, typeError(x.t, "Optional")
+	}
+	if _, null := x.v.GetValue().(*Ydb.Value_NullFlagValue); null {
+		return nil, false, nil
+	}
+	// Value of Optional<T> is stored as is unless T is Optional too.
+	if _, nested := t.T.(OptionalType); nested {
+		return Value{t: t.T, v: x.v.GetNestedValue()}, true, nil
+	}
+	return Value{t: t.T, v: x.v}, true, nil
+}
+
+func ListItems(v V) ([]V, error) {
+	x := v.(Value)
+	t, ok := x.t.(ListType)
+	if !ok {
+		return nil, typeError(x.t, "List")
+	}
+	items := make([]V, len(x.v.GetItems()))
+	for i, item := range x.v.GetItems() {
+		items[i] = Value{t: t.T, v: item}
+	}
+	return items, nil
+}
+
+func TupleItems(v V) ([]V, error) {
+	x := v.(Value)
+	t, ok := x.t.(TupleType)
+	if !ok {
+		return nil, typeError(x.t, "Tuple")
+	}
+	items := make([]V, len(x.v.GetItems()))
+	for i, item := range x.v.GetItems() {
+		items[i] = Value{t: t.Elems[i], v: item}
+	}
+	return items, nil
+}
+
+func StructItems(v V) (names []string, items []V, err error) {
+	x := v.(Value)
+	t, ok := x.t.(StructType)
+	if !ok {
+		return nil, nil, typeError(x.t, "Struct")
+	}
+	names = make([]string, len(x.v.GetItems()))
+	items = make([]V, len(x.v.GetItems()))
+	for i, item := range x.v.GetItems() {
+		names[i] = t.Fields[i].Name
+		items[i] = Value{t: t.Fields[i].Type, v: item}
+	}
+	return names, items, nil
+}
+
+func DictItems(v V) (keys, payloads []V, err error) {
+	x := v.(Value)
+	t, ok := x.t.(DictType)
+	if !ok {
+		return nil, nil, typeError(x.t, "Dict")
+	}
+	keys = make([]V, len(x.v.GetPairs()))
+	payloads = make([]V, len(x.v.GetPairs()))
+	for i, pair := range x.v.GetPairs() {
+		keys[i] = Value{t: t.Key, v: pair.GetKey()}
+		payloads[i] = Value{t: t.Payload, v: pair.GetPayload()}
+	}
+	return keys, payloads, nil
+}
+
+// VariantItem returns filled item of Variant value v.
+// It returns non-empty name of the item for struct-based variant.
+func VariantItem(v V) (name string, index uint32, item V, err error) {
+	x := v.(Value)
+	t, ok := x.t.(VariantType)
+	if !ok {
+		return "", 0, nil, typeError(x.t, "Variant")
+	}
+	index = x.v.GetVariantIndex()
+	itemT, ok := t.at(int(index))
+	if !ok {
+		return "", 0, nil, fmt.Errorf("ydb: no %d-th variant for %s", index, t)
+	}
+	if !t.S.Empty() {
+		name = t.S.Fields[index].Name
+	}
+	return name, index, Value{t: itemT, v: x.v.GetNestedValue()}, nil
+}
+
+func typeError(t T, exp string) error {
+	return fmt.Errorf("ydb: unexpected type %s, exp: %s", t, exp)
+}
@@ -0,0 +1,425 @@
+package value
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+// MarshalJSON returns JSON representation of value v.
+// See types.MarshalJSON for mapping of YDB types.
+func MarshalJSON(v V) ([]byte, error) {
+	var buf bytes.Buffer
+	x := v.(Value)
+	if err := writeJSON(&buf, x.t, x.v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeJSON(buf *bytes.Buffer, t T, v *Ydb.Value) error {
+	switch t := t.(type) {
+	case PrimitiveType:
+		return writePrimitiveJSON(buf, t, v)
+
+	case DecimalType:
+		writeJSONString(buf, decimalText(t, v))
+
+	case VoidType:
+		buf.WriteString("null")
+
+	case OptionalType:
+		if _, null := v.GetValue().(*Ydb.Value_NullFlagValue); null {
+			buf.WriteString("null")
+			return nil
+		}
+		if _, nested := t.T.(OptionalType); nested {
+			buf.WriteByte('[')
+			if err := writeJSON(buf, t.T, v.GetNestedValue()); err != nil {
+				return err
+			}
+			buf.WriteByte(']')
+			return nil
+		}
+		return writeJSON(buf, t.T, v)
+
+	case ListType:
+		buf.WriteByte('[')
+		for i, item := range v.GetItems() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, t.T, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+
+	case TupleType:
+		buf.WriteByte('[')
+		for i, item := range v.GetItems() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, t.Elems[i], item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+
+	case StructType:
+		buf.WriteByte('{')
+		for i, item := range v.GetItems() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, t.Fields[i].Name)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, t.Fields[i].Type, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+
+	case DictType:
+		buf.WriteByte('[')
+		for i, pair := range v.GetPairs() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('[')
+			if err := writeJSON(buf, t.Key, pair.GetKey()); err != nil {
+				return err
+			}
+			buf.WriteByte(',')
+			if err := writeJSON(buf, t.Payload, pair.GetPayload()); err != nil {
+				return err
+			}
+			buf.WriteByte(']')
+		}
+		buf.WriteByte(']')
+
+	case VariantType:
+		i := v.GetVariantIndex()
+		itemT, ok := t.at(int(i))
+		if !ok {
+			return fmt.Errorf("ydb: no %d-th variant for %s", i, t)
+		}
+		buf.WriteByte('[')
+		if t.S.Empty() {
+			buf.WriteString(strconv.FormatUint(uint64(i), 10))
+		} else {
+			writeJSONString(buf, t.S.Fields[i].Name)
+		}
+		buf.WriteByte(',')
+		if err := writeJSON(buf, itemT, v.GetNestedValue()); err != nil {
+			return err
+		}
+		buf.WriteByte(']')
+
+	default:
+		return fmt.Errorf("ydb: json: unsupported type %s", t)
+	}
+	return nil
+}
+
+func writePrimitiveJSON(buf *bytes.Buffer, t PrimitiveType, v *Ydb.Value) error {
+	switch t {
+	case TypeBool,
+		TypeInt8, TypeInt16, TypeInt32, TypeInt64,
+		TypeUint8, TypeUint16, TypeUint32, TypeUint64:
+		buf.WriteString(primitiveText(t, v))
+
+	case TypeFloat, TypeDouble:
+		f := v.GetDoubleValue()
+		if t == TypeFloat {
+			f = float64(v.GetFloatValue())
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			writeJSONString(buf, primitiveText(t, v))
+		} else {
+			buf.WriteString(primitiveText(t, v))
+		}
+
+	case TypeString:
+		buf.WriteByte('"')
+		buf.WriteString(base64.StdEncoding.EncodeToString(v.GetBytesValue()))
+		buf.WriteByte('"')
+
+	case TypeJSON, TypeJSONDocument:
+		s := v.GetTextValue()
+		if !json.Valid([]byte(s)) {
+			return fmt.Errorf("ydb: json: invalid %s value %q", t, s)
+		}
+		buf.WriteString(s)
+
+	default:
+		writeJSONString(buf, primitiveText(t, v))
+	}
+	return nil
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	// Marshaling of string never fails.
+	b, _ := json.Marshal(s)
+	buf.Write(b)
+}
+
+// UnmarshalJSON parses JSON representation of value of type t in format of
+// MarshalJSON. Members of Struct which are missing in data are treated as NULL
+// if they are optional.
+func UnmarshalJSON(data []byte, t T) (V, error) {
+	v, err := fromJSON(data, t)
+	if err != nil {
+		return nil, err
+	}
+	return Value{t: t, v: v}, nil
+}
+
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+func jsonError(t T, data []byte, err error) error {
+	if err != nil {
+		return fmt.Errorf("ydb: json: cannot parse %s from %s: %w", t, data, err)
+	}
+	return fmt.Errorf("ydb: json: cannot parse %s from %s", t, data)
+}
+
+func fromJSON(data []byte, t T) (*Ydb.Value, error) {
+	switch t := t.(type) {
+	case PrimitiveType:
+		return primitiveFromJSON(data, t)
+
+	case DecimalType:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, jsonError(t, data, err)
+		}
+		v, err := ParseText(t, s)
+		if err != nil {
+			return nil, err
+		}
+		return v.(Value).v, nil
+
+	case VoidType:
+		if !isJSONNull(data) {
+			return nil, jsonError(t, data, nil)
+		}
+		return VoidValue.v, nil
+
+	case OptionalType:
+		if isJSONNull(data) {
+			return &Ydb.Value{Value: new(Ydb.Value_NullFlagValue)}, nil
+		}
+		if _, nested := t.T.(OptionalType); nested {
+			var items []json.RawMessage
+			if err := json.Unmarshal(data, &items); err != nil || len(items) != 1 {
+				return nil, jsonError(t, data, err)
+			}
+			v, err := fromJSON(items[0], t.T)
+			if err != nil {
+				return nil, err
+			}
+			return &Ydb.Value{
+				Value: &Ydb.Value_NestedValue{
+					NestedValue: v,
+				},
+			}, nil
+		}
+		return fromJSON(data, t.T)
+
+	case ListType:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, jsonError(t, data, err)
+		}
+		v := &Ydb.Value{
+			Items: make([]*Ydb.Value, len(items)),
+		}
+		for i, item := range items {
+			var err error
+			if v.Items[i], err = fromJSON(item, t.T); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+
+	case TupleType:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, jsonError(t, data, err)
+		}
+		if len(items) != len(t.Elems) {
+			return nil, jsonError(t, data, nil)
+		}
+		v := &Ydb.Value{
+			Items: make([]*Ydb.Value, len(items)),
+		}
+		for i, item := range items {
+			var err error
+			if v.Items[i], err = fromJSON(item, t.Elems[i]); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+
+	case StructType:
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(data, &members); err != nil || members == nil {
+			return nil, jsonError(t, data, err)
+		}
+		v := &Ydb.Value{
+			Items: make([]*Ydb.Value, len(t.Fields)),
+		}
+		for i, f := range t.Fields {
+			member, ok := members[f.Name]
+			if !ok {
+				if _, optional := f.Type.(OptionalType); !optional {
+					return nil, fmt.Errorf("ydb: json: no member %q of %s", f.Name, t)
+				}
+				member = json.RawMessage("null")
+			}
+			delete(members, f.Name)
+			var err error
+			if v.Items[i], err = fromJSON(member, f.Type); err != nil {
+				return nil, err
+			}
+		}
+		for name := range members {
+			return nil, fmt.Errorf("ydb: json: unknown member %q of %s", name, t)
+		}
+		return v, nil
+
+	case DictType:
+		var pairs [][]json.RawMessage
+		if err := json.Unmarshal(data, &pairs); err != nil {
+			return nil, jsonError(t, data, err)
+		}
+		v := &Ydb.Value{
+			Pairs: make([]*Ydb.ValuePair, len(pairs)),
+		}
+		for i, pair := range pairs {
+			if len(pair) != 2 {
+				return nil, jsonError(t, data, nil)
+			}
+			key, err := fromJSON(pair[0], t.Key)
+			if err != nil {
+				return nil, err
+			}
+			payload, err := fromJSON(pair[1], t.Payload)
+			if err != nil {
+				return nil, err
+			}
+			v.Pairs[i] = &Ydb.ValuePair{
+				Key:     key,
+				Payload: payload,
+			}
+		}
+		return v, nil
+
+	case VariantType:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil || len(items) != 2 {
+			return nil, jsonError(t, data, err)
+		}
+		var i uint32
+		if t.S.Empty() {
+			if err := json.Unmarshal(items[0], &i); err != nil {
+				return nil, jsonError(t, data, err)
+			}
+		} else {
+			var name string
+			if err := json.Unmarshal(items[0], &name); err != nil {
+				return nil, jsonError(t, data, err)
+			}
+			i = uint32(len(t.S.Fields))
+			for j, f := range t.S.Fields {
+				if f.Name == name {
+					i = uint32(j)
+					break
+				}
+			}
+		}
+		itemT, ok := t.at(int(i))
+		if !ok {
+			return nil, jsonError(t, data, nil)
+		}
+		item, err := fromJSON(items[1], itemT)
+		if err != nil {
+			return nil, err
+		}
+		return &Ydb.Value{
+			Value: &Ydb.Value_NestedValue{
+				NestedValue: item,
+			},
+			VariantIndex: i,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("ydb: json: unsupported type %s", t)
+	}
+}
+
+func primitiveFromJSON(data []byte, t PrimitiveType) (*Ydb.Value, error) {
+	var s string
+	switch t {
+	case TypeBool:
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return nil, jsonError(t, data, err)
+		}
+		return BoolValue(b).v, nil
+
+	case TypeInt8, TypeInt16, TypeInt32, TypeInt64,
+		TypeUint8, TypeUint16, TypeUint32, TypeUint64:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return nil, jsonError(t, data, err)
+		}
+		s = n.String()
+
+	case TypeFloat, TypeDouble:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			if err = json.Unmarshal(data, &s); err != nil {
+				return nil, jsonError(t, data, err)
+			}
+		} else {
+			s = n.String()
+		}
+
+	case TypeString:
+		var b []byte
+		if err := json.Unmarshal(data, &b); err != nil {
+			return nil, jsonError(t, data, err)
+		}
+		return StringValue(b).v, nil
+
+	case TypeJSON, TypeJSONDocument:
+		if !json.Valid(data) {
+			return nil, jsonError(t, data, nil)
+		}
+		return &Ydb.Value{
+			Value: &Ydb.Value_TextValue{
+				TextValue: string(bytes.TrimSpace(data)),
+			},
+		}, nil
+
+	default:
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, jsonError(t, data, err)
+		}
+	}
+	v, err := ParseText(t, s)
+	if err != nil {
+		return nil, err
+	}
+	return v.(Value).v, nil
+}
@@ -0,0 +1,143 @@
+package value
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		value V
+		exp   string
+	}{
+		{value: BoolValue(true), exp: `true`},
+		{value: Int64Value(-9007199254740993), exp: `-9007199254740993`},
+		{value: Uint8Value(8), exp: `8`},
+		{value: DoubleValue(0.5), exp: `0.5`},
+		{value: FloatValue(float32(math.Inf(1))), exp: `"inf"`},
+		{value: StringValue([]byte{0, 1, 2}), exp: `"AAEC"`},
+		{value: UTF8Value("a\"b"), exp: `"a\"b"`},
+		{value: YSONValue("{a=1}"), exp: `"{a=1}"`},
+		{value: JSONValue(`{"a":[1,2]}`), exp: `{"a":[1,2]}`},
+		{value: JSONDocumentValue(`null`), exp: `null`},
+		{value: DateValue(1), exp: `"1970-01-02"`},
+		{value: DatetimeValue(60), exp: `"1970-01-01T00:01:00Z"`},
+		{value: TimestampValue(1), exp: `"1970-01-01T00:00:00.000001Z"`},
+		{value: TzDateValue("2020-01-01,Europe/Moscow"), exp: `"2020-01-01,Europe/Moscow"`},
+		{value: IntervalValue(-1500000), exp: `"-PT1.5S"`},
+		{value: UUIDValue([16]byte{0: 1}), exp: `"01000000-0000-0000-0000-000000000000"`},
+		{value: DecimalValue(DecimalType{22, 9}, BigEndianUint128(0, 1230000000)), exp: `"1.230000000"`},
+		{value: DyNumberValue("1E2"), exp: `"1E2"`},
+		{value: VoidValue, exp: `null`},
+		{value: NullValue(TypeInt32), exp: `null`},
+		{value: OptionalValue(Int32Value(1)), exp: `1`},
+		{value: NullValue(OptionalType{T: TypeInt32}), exp: `[null]`},
+		{
+			value: Value{
+				t: OptionalType{T: OptionalType{T: TypeInt32}},
+				v: &Ydb.Value{Value: new(Ydb.Value_NullFlagValue)},
+			},
+			exp: `null`,
+		},
+		{
+			value: ListValue(2, func(i int) V { return Int32Value(int32(i)) }),
+			exp:   `[0,1]`,
+		},
+		{value: ZeroValue(ListType{T: TypeInt32}), exp: `[]`},
+		{
+			value: TupleValue(2, func(i int) V {
+				if i == 0 {
+					return UTF8Value("a")
+				}
+				return NullValue(TypeBool)
+			}),
+			exp: `["a",null]`,
+		},
+		{
+			value: StructValue(&StructValueProto{
+				Fields: []StructField{{"b", TypeBool}, {"a", TypeUTF8}},
+				Values: []*Ydb.Value{BoolValue(true).v, UTF8Value("x").v},
+			}),
+			exp: `{"b":true,"a":"x"}`,
+		},
+		{
+			value: DictValue(2, func(i int) V {
+				if i == 0 {
+					return Int32Value(1)
+				}
+				return UTF8Value("a")
+			}),
+			exp: `[[1,"a"]]`,
+		},
+		{
+			value: VariantValue(Int32Value(42), 1, VariantType{T: TupleType{Elems: []T{TypeUTF8, TypeInt32}}}),
+			exp:   `[1,42]`,
+		},
+		{
+			value: VariantValue(Int32Value(42), 1, VariantType{S: StructType{Fields: []StructField{
+				{"foo", TypeUTF8},
+				{"bar", TypeInt32},
+			}}}),
+			exp: `["bar",42]`,
+		},
+	} {
+		t.Run(test.exp, func(t *testing.T) {
+			act, err := MarshalJSON(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(act) != test.exp {
+				t.Fatalf("unexpected json: %s, exp: %s", act, test.exp)
+			}
+			v, err := UnmarshalJSON(act, test.value.(Value).t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(v.ToYDB(), test.value.ToYDB()) {
+				t.Fatalf("unexpected unmarshaled value: %s, exp: %s", v, test.value)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	structT := StructType{Fields: []StructField{
+		{"a", TypeInt32},
+		{"b", OptionalType{T: TypeUTF8}},
+	}}
+	v, err := UnmarshalJSON([]byte(`{"a":1}`), structT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := StructValue(&StructValueProto{
+		Fields: structT.Fields,
+		Values: []*Ydb.Value{Int32Value(1).v, NullValue(TypeUTF8).v},
+	})
+	if !proto.Equal(v.ToYDB(), exp.ToYDB()) {
+		t.Errorf("unexpected value: %s, exp: %s", v, exp)
+	}
+	for _, test := range []struct {
+		t    T
+		data string
+	}{
+		{t: TypeInt8, data: `128`},
+		{t: TypeUint64, data: `1.5`},
+		{t: TypeBool, data: `1`},
+		{t: TypeString, data: `"!"`},
+		{t: TypeJSON, data: `{`},
+		{t: TypeDate, data: `"2020-01-01T00:00:00Z"`},
+		{t: TypeInt32, data: `null`},
+		{t: TupleType{Elems: []T{TypeInt32}}, data: `[1,2]`},
+		{t: structT, data: `{"b":"x"}`},
+		{t: structT, data: `{"a":1,"c":2}`},
+		{t: VariantType{T: TupleType{Elems: []T{TypeInt32}}}, data: `[1,1]`},
+		{t: OptionalType{T: OptionalType{T: TypeInt32}}, data: `1`},
+	} {
+		if _, err := UnmarshalJSON([]byte(test.data), test.t); err == nil {
+			t.Errorf("expected error for %s as %s", test.data, test.t)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package value
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseType parses YQL type string s such as "Optional<List<Struct<a:Int32,b:Utf8>>>".
+//
+// Type names are case-insensitive. Optional may be written as T? shorthand.
+// Variant may be written both in YQL form (Variant<Int32,Utf8>, Variant<a:Int32,b:Utf8>)
+// and in form of WriteTypeStringTo (Variant<Tuple<Int32,Utf8>>).
+// Tagged<T,'tag'> is parsed into T, because tags are not kept by YDB types here.
+func ParseType(s string) (T, error) {
+	p := typeParser{s: s}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return t, nil
+}
+
+type typeParser struct {
+	s   string
+	pos int
+}
+
+func (p *typeParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(
+		"ydb: parse type %q at %d: %s",
+		p.s, p.pos, fmt.Sprintf(format, args...),
+	)
+}
+
+func (p *typeParser) skipSpaces() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips spaces and consumes byte c if it is the next one.
+func (p *typeParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *typeParser) expect(c byte) error {
+	if !p.consume(c) {
+		if p.pos == len(p.s) {
+			return p.errorf("expected %q, got end of string", c)
+		}
+		return p.errorf("expected %q, got %q", c, p.s[p.pos])
+	}
+	return nil
+}
+
+func (p *typeParser) ident() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := rune(p.s[p.pos])
+		if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// name parses struct member name or tag which may be quoted.
+func (p *typeParser) name() (string, error) {
+	p.skipSpaces()
+	if p.pos < len(p.s) {
+		switch q := p.s[p.pos]; q {
+		case '\'', '"', '`':
+			var name strings.Builder
+			for i := p.pos + 1; i < len(p.s); i++ {
+				switch c := p.s[i]; {
+				case c == q:
+					p.pos = i + 1
+					return name.String(), nil
+				case c == '\\' && i+1 < len(p.s):
+					i++
+					name.WriteByte(p.s[i])
+				default:
+					name.WriteByte(c)
+				}
+			}
+			return "", p.errorf("unterminated name")
+		}
+	}
+	name := p.ident()
+	if name == "" {
+		return "", p.errorf("expected name")
+	}
+	return name, nil
+}
+
+func (p *typeParser) uint32() (uint32, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && '0' <= p.s[p.pos] && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	n, err := strconv.ParseUint(p.s[start:p.pos], 10, 32)
+	if err != nil {
+		return 0, p.errorf("expected number")
+	}
+	return uint32(n), nil
+}
+
+func (p *typeParser) parseType() (t T, err error) {
+	t, err = p.parseNonOptional()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume('?') {
+		t = OptionalType{T: t}
+	}
+	return t, nil
+}
+
+func (p *typeParser) parseNonOptional() (T, error) {
+	name := p.ident()
+	if name == "" {
+		return nil, p.errorf("expected type name")
+	}
+	switch strings.ToLower(name) {
+	case "optional":
+		return p.parseOne(func(t T) T { return OptionalType{T: t} })
+
+	case "list":
+		return p.parseOne(func(t T) T { return ListType{T: t} })
+
+	case "tuple":
+		elems, err := p.parseTypes()
+		if err != nil {
+			return nil, err
+		}
+		return TupleType{Elems: elems}, nil
+
+	case "struct":
+		fields, err := p.parseFields()
+		if err != nil {
+			return nil, err
+		}
+		return StructType{Fields: fields}, nil
+
+	case "dict":
+		elems, err := p.parseTypes()
+		if err != nil {
+			return nil, err
+		}
+		if len(elems) != 2 {
+			return nil, p.errorf("dict must have key and payload types")
+		}
+		return DictType{Key: elems[0], Payload: elems[1]}, nil
+
+	case "variant":
+		return p.parseVariant()
+
+	case "tagged":
+		return p.parseTagged()
+
+	case "decimal":
+		return p.parseDecimal()
+
+	case "void":
+		return VoidType{}, nil
+	}
+	for i, s := range primitiveString {
+		if i != int(TypeUnknown) && strings.EqualFold(s, name) {
+			return PrimitiveType(i), nil
+		}
+	}
+	return nil, p.errorf("unknown type %q", name)
+}
+
+func (p *typeParser) parseOne(f func(T) T) (T, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect('>'); err != nil {
+		return nil, err
+	}
+	return f(t), nil
+}
+
+func (p *typeParser) parseTypes() (ts []T, err error) {
+	if err = p.expect('<'); err != nil {
+		return nil, err
+	}
+	if p.consume('>') {
+		return ts, nil
+	}
+	for {
+		var t T
+		t, err = p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		ts = append(ts, t)
+		if p.consume('>') {
+			return ts, nil
+		}
+		if err = p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *typeParser) parseFields() (fs []StructField, err error) {
+	if err = p.expect('<'); err != nil {
+		return nil, err
+	}
+	if p.consume('>') {
+		return fs, nil
+	}
+	for {
+		var f StructField
+		f, err = p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, f)
+		if p.consume('>') {
+			return fs, nil
+		}
+		if err = p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *typeParser) parseField() (f StructField, err error) {
+	f.Name, err = p.name()
+	if err != nil {
+		return f, err
+	}
+	if err = p.expect(':'); err != nil {
+		return f, err
+	}
+	f.Type, err = p.parseType()
+	return f, err
+}
+
+func (p *typeParser) parseVariant() (T, error) {
+	// Variant over struct has named items, so look ahead for name and colon.
+	start := p.pos
+	isStruct := p.consume('<')
+	if isStruct {
+		_, err := p.name()
+		isStruct = err == nil && p.consume(':')
+	}
+	p.pos = start
+
+	if isStruct {
+		fields, err := p.parseFields()
+		if err != nil {
+			return nil, err
+		}
+		return VariantType{S: StructType{Fields: fields}}, nil
+	}
+	elems, err := p.parseTypes()
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) == 1 {
+		switch t := elems[0].(type) {
+		case TupleType:
+			return VariantType{T: t}, nil
+		case StructType:
+			return VariantType{S: t}, nil
+		}
+	}
+	return VariantType{T: TupleType{Elems: elems}}, nil
+}
+
+func (p *typeParser) parseTagged() (T, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+	if _, err = p.name(); err != nil {
+		return nil, err
+	}
+	if err = p.expect('>'); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// maxDecimalPrecision is a maximum precision of YDB decimals.
+const maxDecimalPrecision = 35
+
+// checkDecimal returns error if precision or scale of decimal type is invalid.
+func checkDecimal(precision, scale uint32) error {
+	if precision < 1 || precision > maxDecimalPrecision {
+		return fmt.Errorf("decimal precision %d is out of range [1, %d]", precision, maxDecimalPrecision)
+	}
+	if scale > precision {
+		return fmt.Errorf("decimal scale %d is greater than precision %d", scale, precision)
+	}
+	return nil
+}
+
+func (p *typeParser) parseDecimal() (T, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	precision, err := p.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+	scale, err := p.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(')'); err != nil {
+		return nil, err
+	}
+	if err = checkDecimal(precision, scale); err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	return DecimalType{Precision: precision, Scale: scale}, nil
+}
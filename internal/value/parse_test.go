@@ -0,0 +1,116 @@
+package value
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseType(t *testing.T) {
+	for _, test := range []struct {
+		s   string
+		exp T
+	}{
+		{s: "Int32", exp: TypeInt32},
+		{s: "utf8", exp: TypeUTF8},
+		{s: "JsonDocument", exp: TypeJSONDocument},
+		{s: "Int32?", exp: OptionalType{T: TypeInt32}},
+		{s: "Decimal(22, 9)", exp: DecimalType{Precision: 22, Scale: 9}},
+		{s: "Decimal(35,35)", exp: DecimalType{Precision: 35, Scale: 35}},
+		{s: "Void", exp: VoidType{}},
+		{
+			s: "Optional<List<Struct<a:Int32,b:Utf8>>>",
+			exp: OptionalType{T: ListType{T: StructType{Fields: []StructField{
+				{Name: "a", Type: TypeInt32},
+				{Name: "b", Type: TypeUTF8},
+			}}}},
+		},
+		{
+			s:   "Tuple<Int32, String?>",
+			exp: TupleType{Elems: []T{TypeInt32, OptionalType{T: TypeString}}},
+		},
+		{
+			s:   "Dict<Utf8,List<Uint64>>",
+			exp: DictType{Key: TypeUTF8, Payload: ListType{T: TypeUint64}},
+		},
+		{
+			s:   "Variant<Int32,Utf8>",
+			exp: VariantType{T: TupleType{Elems: []T{TypeInt32, TypeUTF8}}},
+		},
+		{
+			s:   "Variant<Tuple<Int32,Utf8>>",
+			exp: VariantType{T: TupleType{Elems: []T{TypeInt32, TypeUTF8}}},
+		},
+		{
+			s: "Variant<'a':Int32,`b`:Utf8>",
+			exp: VariantType{S: StructType{Fields: []StructField{
+				{Name: "a", Type: TypeInt32},
+				{Name: "b", Type: TypeUTF8},
+			}}},
+		},
+		{
+			s: "Variant<Struct<a:Int32>>",
+			exp: VariantType{S: StructType{Fields: []StructField{
+				{Name: "a", Type: TypeInt32},
+			}}},
+		},
+		{s: "Tagged<Utf8,'tag'>", exp: TypeUTF8},
+	} {
+		t.Run(test.s, func(t *testing.T) {
+			act, err := ParseType(test.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !TypesEqual(act, test.exp) {
+				t.Fatalf("unexpected type: %s, exp: %s", act, test.exp)
+			}
+			// Type string must be parsed back into the same type.
+			var buf bytes.Buffer
+			WriteTypeStringTo(&buf, act)
+			act, err = ParseType(buf.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !TypesEqual(act, test.exp) {
+				t.Fatalf("unexpected type: %s, exp: %s", act, test.exp)
+			}
+		})
+	}
+}
+
+func TestParseTypeQuotedName(t *testing.T) {
+	exp := StructType{Fields: []StructField{
+		{Name: "select", Type: TypeInt32},
+		{Name: "a`b\\c", Type: TypeUTF8},
+	}}
+	s := FormatTypeYQL(exp)
+	if s != "Struct<`select`:Int32,`a\\`b\\\\c`:Utf8>" {
+		t.Fatalf("unexpected yql: %s", s)
+	}
+	act, err := ParseType(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !TypesEqual(act, exp) {
+		t.Fatalf("unexpected type: %s, exp: %s", act, exp)
+	}
+}
+
+func TestParseTypeError(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"Int",
+		"List<Int32",
+		"List<Int32>>",
+		"Dict<Int32>",
+		"Struct<a Int32>",
+		"Decimal(22)",
+		"Decimal(0,0)",
+		"Decimal(40,2)",
+		"Decimal(5,9)",
+		"Tagged<Int32>",
+	} {
+		if _, err := ParseType(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
@@ -388,6 +388,9 @@ func (v VariantType) String() string {
 }
 
 func (v VariantType) at(i int) (T, bool) {
+	if i < 0 {
+		return nil, false
+	}
 	if v.S.Empty() {
 		if len(v.T.Elems) <= i {
 			return nil, false
@@ -0,0 +1,968 @@
+package value
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/timeutil"
+)
+
+// Text layouts of date and time primitives.
+const (
+	layoutDate      = "2006-01-02"
+	layoutDatetime  = "2006-01-02T15:04:05Z"
+	layoutTimestamp = "2006-01-02T15:04:05.000000Z"
+)
+
+// FormatYQL returns YQL literal of value v, e.g. Timestamp("2020-01-01T00:00:00.000000Z"),
+// Decimal("1.230000000",22,9), AsList(Int32("1")), AsStruct(Utf8("a") AS `name`)
+// or Nothing(Optional<Int32>).
+func FormatYQL(v V) string {
+	var buf bytes.Buffer
+	x := v.(Value)
+	writeYQL(&buf, x.t, x.v)
+	return buf.String()
+}
+
+func writeYQL(buf *bytes.Buffer, t T, v *Ydb.Value) {
+	switch t := t.(type) {
+	case PrimitiveType:
+		if t == TypeBool {
+			buf.WriteString(strconv.FormatBool(v.GetBoolValue()))
+			return
+		}
+		buf.WriteString(t.String())
+		buf.WriteByte('(')
+		writeQuoted(buf, primitiveText(t, v), t == TypeString)
+		buf.WriteByte(')')
+
+	case DecimalType:
+		buf.WriteString("Decimal(")
+		writeQuoted(buf, decimalText(t, v), false)
+		fmt.Fprintf(buf, ",%d,%d)", t.Precision, t.Scale)
+
+	case VoidType:
+		buf.WriteString("Void()")
+
+	case OptionalType:
+		if _, null := v.GetValue().(*Ydb.Value_NullFlagValue); null {
+			buf.WriteString("Nothing(")
+			writeTypeYQL(buf, t)
+			buf.WriteByte(')')
+			return
+		}
+		if _, nested := t.T.(OptionalType); nested {
+			v = v.GetNestedValue()
+		}
+		buf.WriteString("Just(")
+		writeYQL(buf, t.T, v)
+		buf.WriteByte(')')
+
+	case ListType:
+		if len(v.GetItems()) == 0 {
+			buf.WriteString("ListCreate(")
+			writeTypeYQL(buf, t.T)
+			buf.WriteByte(')')
+			return
+		}
+		buf.WriteString("AsList(")
+		for i, item := range v.GetItems() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeYQL(buf, t.T, item)
+		}
+		buf.WriteByte(')')
+
+	case TupleType:
+		buf.WriteString("AsTuple(")
+		for i, item := range v.GetItems() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeYQL(buf, t.Elems[i], item)
+		}
+		buf.WriteByte(')')
+
+	case StructType:
+		buf.WriteString("AsStruct(")
+		for i, item := range v.GetItems() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeYQL(buf, t.Fields[i].Type, item)
+			buf.WriteString(" AS ")
+			writeName(buf, t.Fields[i].Name)
+		}
+		buf.WriteByte(')')
+
+	case DictType:
+		if len(v.GetPairs()) == 0 {
+			buf.WriteString("DictCreate(")
+			writeTypeYQL(buf, t.Key)
+			buf.WriteByte(',')
+			writeTypeYQL(buf, t.Payload)
+			buf.WriteByte(')')
+			return
+		}
+		buf.WriteString("AsDict(")
+		for i, pair := range v.GetPairs() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString("AsTuple(")
+			writeYQL(buf, t.Key, pair.GetKey())
+			buf.WriteByte(',')
+			writeYQL(buf, t.Payload, pair.GetPayload())
+			buf.WriteByte(')')
+		}
+		buf.WriteByte(')')
+
+	case VariantType:
+		i := v.GetVariantIndex()
+		itemT, _ := t.at(int(i))
+		buf.WriteString("Variant(")
+		writeYQL(buf, itemT, v.GetNestedValue())
+		buf.WriteByte(',')
+		if t.S.Empty() {
+			writeQuoted(buf, strconv.FormatUint(uint64(i), 10), false)
+		} else {
+			writeQuoted(buf, t.S.Fields[i].Name, false)
+		}
+		buf.WriteByte(',')
+		writeTypeYQL(buf, t)
+		buf.WriteByte(')')
+	}
+}
+
+// FormatTypeYQL returns type t in YQL syntax which may be used in DECLARE.
+func FormatTypeYQL(t T) string {
+	var buf bytes.Buffer
+	writeTypeYQL(&buf, t)
+	return buf.String()
+}
+
+// writeTypeYQL writes type t in YQL syntax. Unlike WriteTypeStringTo it writes
+// Variant items without underlying Tuple or Struct and quotes struct member
+// names if it is needed.
+func writeTypeYQL(buf *bytes.Buffer, t T) {
+	switch t := t.(type) {
+	case OptionalType:
+		buf.WriteString("Optional<")
+		writeTypeYQL(buf, t.T)
+		buf.WriteByte('>')
+
+	case ListType:
+		buf.WriteString("List<")
+		writeTypeYQL(buf, t.T)
+		buf.WriteByte('>')
+
+	case TupleType:
+		buf.WriteString("Tuple<")
+		writeTypesYQL(buf, t.Elems)
+		buf.WriteByte('>')
+
+	case StructType:
+		buf.WriteString("Struct<")
+		writeFieldsYQL(buf, t.Fields)
+		buf.WriteByte('>')
+
+	case DictType:
+		buf.WriteString("Dict<")
+		writeTypeYQL(buf, t.Key)
+		buf.WriteByte(',')
+		writeTypeYQL(buf, t.Payload)
+		buf.WriteByte('>')
+
+	case VariantType:
+		buf.WriteString("Variant<")
+		if t.S.Empty() {
+			writeTypesYQL(buf, t.T.Elems)
+		} else {
+			writeFieldsYQL(buf, t.S.Fields)
+		}
+		buf.WriteByte('>')
+
+	default:
+		t.toString(buf)
+	}
+}
+
+func writeTypesYQL(buf *bytes.Buffer, ts []T) {
+	for i, t := range ts {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeTypeYQL(buf, t)
+	}
+}
+
+func writeFieldsYQL(buf *bytes.Buffer, fs []StructField) {
+	for i, f := range fs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeName(buf, f.Name)
+		buf.WriteByte(':')
+		writeTypeYQL(buf, f.Type)
+	}
+}
+
+// writeName writes name quoted with backticks, so keywords and arbitrary
+// characters are allowed in names.
+func writeName(buf *bytes.Buffer, name string) {
+	buf.WriteByte('`')
+	nameEscaper.WriteString(buf, name)
+	buf.WriteByte('`')
+}
+
+var nameEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+
+// writeQuoted writes s as double-quoted YQL string literal. If binary is true
+// all of non-ASCII bytes are escaped, otherwise s is treated as UTF-8 text.
+func writeQuoted(buf *bytes.Buffer, s string, binary bool) {
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f || (c >= utf8.RuneSelf && binary) {
+				fmt.Fprintf(buf, `\x%02X`, c)
+				continue
+			}
+			if c >= utf8.RuneSelf {
+				r, n := utf8.DecodeRuneInString(s[i:])
+				if r == utf8.RuneError && n == 1 {
+					fmt.Fprintf(buf, `\x%02X`, c)
+					continue
+				}
+				buf.WriteString(s[i : i+n])
+				i += n - 1
+				continue
+			}
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// PrimitiveText returns text representation of primitive or decimal value v,
+// e.g. "2020-01-01" for Date or "1.23" for Decimal(22,9).
+func PrimitiveText(v V) (string, error) {
+	x := v.(Value)
+	switch t := x.t.(type) {
+	case PrimitiveType:
+		return primitiveText(t, x.v), nil
+	case DecimalType:
+		return decimalText(t, x.v), nil
+	default:
+		return "", typeError(x.t, "primitive")
+	}
+}
+
+func primitiveText(t PrimitiveType, v *Ydb.Value) string {
+	switch t {
+	case TypeBool:
+		return strconv.FormatBool(v.GetBoolValue())
+	case TypeInt8, TypeInt16, TypeInt32:
+		return strconv.FormatInt(int64(v.GetInt32Value()), 10)
+	case TypeUint8, TypeUint16, TypeUint32:
+		return strconv.FormatUint(uint64(v.GetUint32Value()), 10)
+	case TypeInt64:
+		return strconv.FormatInt(v.GetInt64Value(), 10)
+	case TypeUint64:
+		return strconv.FormatUint(v.GetUint64Value(), 10)
+	case TypeFloat:
+		return formatFloat(float64(v.GetFloatValue()), 32)
+	case TypeDouble:
+		return formatFloat(v.GetDoubleValue(), 64)
+	case TypeDate:
+		return timeutil.UnmarshalDate(v.GetUint32Value()).UTC().Format(layoutDate)
+	case TypeDatetime:
+		return timeutil.UnmarshalDatetime(v.GetUint32Value()).UTC().Format(layoutDatetime)
+	case TypeTimestamp:
+		return timeutil.UnmarshalTimestamp(v.GetUint64Value()).UTC().Format(layoutTimestamp)
+	case TypeInterval:
+		return formatInterval(v.GetInt64Value())
+	case TypeString:
+		return string(v.GetBytesValue())
+	case TypeUUID:
+		return formatUUID(BigEndianUint128(v.GetHigh_128(), v.GetLow_128()))
+	default:
+		return v.GetTextValue()
+	}
+}
+
+func decimalText(t DecimalType, v *Ydb.Value) string {
+	b := BigEndianUint128(v.GetHigh_128(), v.GetLow_128())
+	return decimal.Format(decimal.FromInt128(b, t.Precision, t.Scale), t.Precision, t.Scale)
+}
+
+func formatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	}
+}
+
+// formatInterval formats interval of n microseconds in ISO 8601 format.
+func formatInterval(n int64) string {
+	var buf bytes.Buffer
+	u := uint64(n)
+	if n < 0 {
+		buf.WriteByte('-')
+		u = uint64(-n)
+	}
+	const (
+		second = uint64(time.Second / time.Microsecond)
+		minute = 60 * second
+		hour   = 60 * minute
+		day    = 24 * hour
+	)
+	buf.WriteByte('P')
+	if d := u / day; d > 0 {
+		buf.WriteString(strconv.FormatUint(d, 10))
+		buf.WriteByte('D')
+		u %= day
+	}
+	if u == 0 && buf.Len() > 2 {
+		return buf.String()
+	}
+	buf.WriteByte('T')
+	if h := u / hour; h > 0 {
+		buf.WriteString(strconv.FormatUint(h, 10))
+		buf.WriteByte('H')
+		u %= hour
+	}
+	if m := u / minute; m > 0 {
+		buf.WriteString(strconv.FormatUint(m, 10))
+		buf.WriteByte('M')
+		u %= minute
+	}
+	if u > 0 || buf.Bytes()[buf.Len()-1] == 'T' {
+		buf.WriteString(strconv.FormatUint(u/second, 10))
+		if f := u % second; f > 0 {
+			buf.WriteString(strings.TrimRight(fmt.Sprintf(".%06d", f), "0"))
+		}
+		buf.WriteByte('S')
+	}
+	return buf.String()
+}
+
+// parseInterval parses interval in ISO 8601 format into microseconds.
+func parseInterval(s string) (int64, error) {
+	in := s
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 2 {
+		return 0, fmt.Errorf("ydb: invalid interval %q", in)
+	}
+	s = s[1:]
+	const second = float64(time.Second / time.Microsecond)
+	var (
+		n    float64
+		time bool
+	)
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if time || len(s) == 1 {
+				return 0, fmt.Errorf("ydb: invalid interval %q", in)
+			}
+			time = true
+			s = s[1:]
+			continue
+		}
+		i := strings.IndexAny(s, "WDHMS")
+		if i <= 0 {
+			return 0, fmt.Errorf("ydb: invalid interval %q", in)
+		}
+		x, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil || x < 0 {
+			return 0, fmt.Errorf("ydb: invalid interval %q", in)
+		}
+		switch unit := s[i]; {
+		case unit == 'W' && !time:
+			x *= 7 * 24 * 60 * 60 * second
+		case unit == 'D' && !time:
+			x *= 24 * 60 * 60 * second
+		case unit == 'H' && time:
+			x *= 60 * 60 * second
+		case unit == 'M' && time:
+			x *= 60 * second
+		case unit == 'S' && time:
+			x *= second
+		default:
+			return 0, fmt.Errorf("ydb: invalid interval %q", in)
+		}
+		n += x
+		s = s[i+1:]
+	}
+	if neg {
+		n = -n
+	}
+	return int64(math.Round(n)), nil
+}
+
+func formatUUID(b [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return string(buf[:])
+}
+
+func parseUUID(s string) (b [16]byte, err error) {
+	x := strings.ReplaceAll(s, "-", "")
+	if len(x) != 32 || len(s) != 36 {
+		return b, fmt.Errorf("ydb: invalid uuid %q", s)
+	}
+	if _, err = hex.Decode(b[:], []byte(x)); err != nil {
+		return b, fmt.Errorf("ydb: invalid uuid %q: %w", s, err)
+	}
+	return b, nil
+}
+
+// ParseText parses text representation s of primitive or decimal value of type t.
+// It is the inverse of PrimitiveText.
+func ParseText(t T, s string) (V, error) {
+	switch t := t.(type) {
+	case PrimitiveType:
+		return parsePrimitiveText(t, s)
+	case DecimalType:
+		if err := checkDecimal(t.Precision, t.Scale); err != nil {
+			return nil, err
+		}
+		x, err := decimal.Parse(s, t.Precision, t.Scale)
+		if err != nil {
+			return nil, err
+		}
+		return DecimalValue(t, decimal.BigIntToByte(x, t.Precision, t.Scale)), nil
+	default:
+		return nil, typeError(t, "primitive")
+	}
+}
+
+func parsePrimitiveText(t PrimitiveType, s string) (v V, err error) {
+	parseError := func(err error) error {
+		return fmt.Errorf("ydb: cannot parse %q as %s: %w", s, t, err)
+	}
+	switch t {
+	case TypeBool:
+		x, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, parseError(err)
+		}
+		return BoolValue(x), nil
+	case TypeInt8, TypeInt16, TypeInt32, TypeInt64, TypeInterval:
+		var x int64
+		if t == TypeInterval {
+			x, err = parseInterval(s)
+		} else {
+			x, err = strconv.ParseInt(s, 10, intBits(t))
+		}
+		if err != nil {
+			return nil, parseError(err)
+		}
+		switch t {
+		case TypeInt8:
+			return Int8Value(int8(x)), nil
+		case TypeInt16:
+			return Int16Value(int16(x)), nil
+		case TypeInt32:
+			return Int32Value(int32(x)), nil
+		case TypeInt64:
+			return Int64Value(x), nil
+		default:
+			return IntervalValue(x), nil
+		}
+	case TypeUint8, TypeUint16, TypeUint32, TypeUint64:
+		x, err := strconv.ParseUint(s, 10, intBits(t))
+		if err != nil {
+			return nil, parseError(err)
+		}
+		switch t {
+		case TypeUint8:
+			return Uint8Value(uint8(x)), nil
+		case TypeUint16:
+			return Uint16Value(uint16(x)), nil
+		case TypeUint32:
+			return Uint32Value(uint32(x)), nil
+		default:
+			return Uint64Value(x), nil
+		}
+	case TypeFloat:
+		x, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return nil, parseError(err)
+		}
+		return FloatValue(float32(x)), nil
+	case TypeDouble:
+		x, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, parseError(err)
+		}
+		return DoubleValue(x), nil
+	case TypeDate:
+		x, err := time.Parse(layoutDate, s)
+		if err != nil {
+			return nil, parseError(err)
+		}
+		return DateValue(timeutil.MarshalDate(x)), nil
+	case TypeDatetime:
+		x, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, parseError(err)
+		}
+		return DatetimeValue(timeutil.MarshalDatetime(x)), nil
+	case TypeTimestamp:
+		x, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, parseError(err)
+		}
+		return TimestampValue(timeutil.MarshalTimestamp(x)), nil
+	case TypeTzDate:
+		return TzDateValue(s), nil
+	case TypeTzDatetime:
+		return TzDatetimeValue(s), nil
+	case TypeTzTimestamp:
+		return TzTimestampValue(s), nil
+	case TypeString:
+		return StringValue([]byte(s)), nil
+	case TypeUTF8:
+		return UTF8Value(s), nil
+	case TypeYSON:
+		return YSONValue(s), nil
+	case TypeJSON:
+		return JSONValue(s), nil
+	case TypeJSONDocument:
+		return JSONDocumentValue(s), nil
+	case TypeDyNumber:
+		return DyNumberValue(s), nil
+	case TypeUUID:
+		x, err := parseUUID(s)
+		if err != nil {
+			return nil, err
+		}
+		return UUIDValue(x), nil
+	default:
+		return nil, fmt.Errorf("ydb: cannot parse %q as %s", s, t)
+	}
+}
+
+func intBits(t PrimitiveType) int {
+	switch t {
+	case TypeInt8, TypeUint8:
+		return 8
+	case TypeInt16, TypeUint16:
+		return 16
+	case TypeInt32, TypeUint32:
+		return 32
+	default:
+		return 64
+	}
+}
+
+// ParseYQL parses YQL literal s in format of FormatYQL.
+func ParseYQL(s string) (V, error) {
+	p := yqlParser{typeParser{s: s}}
+	v, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return v, nil
+}
+
+type yqlParser struct {
+	typeParser
+}
+
+func (p *yqlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(
+		"ydb: parse yql %q at %d: %s",
+		p.s, p.pos, fmt.Sprintf(format, args...),
+	)
+}
+
+// str parses quoted string literal.
+func (p *yqlParser) str() (string, error) {
+	p.skipSpaces()
+	if p.pos == len(p.s) || (p.s[p.pos] != '"' && p.s[p.pos] != '\'') {
+		return "", p.errorf("expected string literal")
+	}
+	q := p.s[p.pos]
+	p.pos++
+	var buf bytes.Buffer
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		p.pos++
+		switch {
+		case c == q:
+			return buf.String(), nil
+		case c != '\\':
+			buf.WriteByte(c)
+		case p.pos == len(p.s):
+			return "", p.errorf("unterminated string literal")
+		default:
+			c = p.s[p.pos]
+			p.pos++
+			switch c {
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case 'x':
+				if p.pos+2 > len(p.s) {
+					return "", p.errorf("invalid escape sequence")
+				}
+				b, err := strconv.ParseUint(p.s[p.pos:p.pos+2], 16, 8)
+				if err != nil {
+					return "", p.errorf("invalid escape sequence")
+				}
+				buf.WriteByte(byte(b))
+				p.pos += 2
+			default:
+				buf.WriteByte(c)
+			}
+		}
+	}
+	return "", p.errorf("unterminated string literal")
+}
+
+func (p *yqlParser) parseValues(items []V) ([]V, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	if p.consume(')') {
+		return items, nil
+	}
+	for {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+		if p.consume(')') {
+			return items, nil
+		}
+		if err = p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *yqlParser) parseTypeArg() (T, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	return t, p.expect(')')
+}
+
+func (p *yqlParser) parseValue() (V, error) {
+	start := p.pos
+	name := p.ident()
+	switch strings.ToLower(name) {
+	case "":
+		return nil, p.errorf("expected value")
+
+	case "true", "false":
+		return BoolValue(strings.EqualFold(name, "true")), nil
+
+	case "void":
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		return VoidValue, p.expect(')')
+
+	case "just":
+		items, err := p.parseValues(nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) != 1 {
+			return nil, p.errorf("Just must have one argument")
+		}
+		return OptionalValue(items[0]), nil
+
+	case "nothing":
+		t, err := p.parseTypeArg()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := t.(OptionalType); !ok {
+			return nil, p.errorf("Nothing must have optional type, got %s", t)
+		}
+		// NullValue nests NULL into optional items, so build outer NULL here.
+		return Value{t: t, v: &Ydb.Value{Value: new(Ydb.Value_NullFlagValue)}}, nil
+
+	case "aslist":
+		items, err := p.parseValues(nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, p.errorf("AsList must have arguments, use ListCreate for empty list")
+		}
+		for _, item := range items[1:] {
+			if !TypesEqual(item.(Value).t, items[0].(Value).t) {
+				return nil, p.errorf("list items have different types")
+			}
+		}
+		return ListValue(len(items), func(i int) V { return items[i] }), nil
+
+	case "listcreate":
+		t, err := p.parseTypeArg()
+		if err != nil {
+			return nil, err
+		}
+		return Value{t: ListType{T: t}, v: &Ydb.Value{}}, nil
+
+	case "astuple":
+		items, err := p.parseValues(nil)
+		if err != nil {
+			return nil, err
+		}
+		return TupleValue(len(items), func(i int) V { return items[i] }), nil
+
+	case "asstruct":
+		return p.parseStruct()
+
+	case "asdict":
+		return p.parseDict()
+
+	case "dictcreate":
+		ts, err := p.parseTypeList()
+		if err != nil {
+			return nil, err
+		}
+		return Value{t: DictType{Key: ts[0], Payload: ts[1]}, v: &Ydb.Value{}}, nil
+
+	case "variant":
+		return p.parseVariantValue()
+
+	case "decimal":
+		return p.parseDecimalValue()
+	}
+	p.pos = start
+	t, err := p.parseNonOptional()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect('('); err != nil {
+		return nil, err
+	}
+	s, err := p.str()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(')'); err != nil {
+		return nil, err
+	}
+	v, err := ParseText(t, s)
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	return v, nil
+}
+
+// parseTypeList parses two comma separated types in parentheses.
+func (p *yqlParser) parseTypeList() (ts [2]T, err error) {
+	if err = p.expect('('); err != nil {
+		return ts, err
+	}
+	if ts[0], err = p.parseType(); err != nil {
+		return ts, err
+	}
+	if err = p.expect(','); err != nil {
+		return ts, err
+	}
+	if ts[1], err = p.parseType(); err != nil {
+		return ts, err
+	}
+	return ts, p.expect(')')
+}
+
+func (p *yqlParser) parseStruct() (V, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var s StructValueProto
+	if p.consume(')') {
+		return StructValue(&s), nil
+	}
+	for {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if as := p.ident(); !strings.EqualFold(as, "as") {
+			return nil, p.errorf("expected AS")
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		s.Add(name, v)
+		if p.consume(')') {
+			return StructValue(&s), nil
+		}
+		if err = p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *yqlParser) parseDict() (V, error) {
+	items, err := p.parseValues(nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, p.errorf("AsDict must have arguments, use DictCreate for empty dict")
+	}
+	pairs := make([]V, 0, 2*len(items))
+	for _, item := range items {
+		t, ok := item.(Value).t.(TupleType)
+		if !ok || len(t.Elems) != 2 {
+			return nil, p.errorf("AsDict arguments must be tuples of key and payload")
+		}
+		x := item.(Value)
+		k := Value{t: t.Elems[0], v: x.v.GetItems()[0]}
+		v := Value{t: t.Elems[1], v: x.v.GetItems()[1]}
+		if len(pairs) > 0 && (!TypesEqual(k.t, pairs[0].(Value).t) || !TypesEqual(v.t, pairs[1].(Value).t)) {
+			return nil, p.errorf("dict pairs have different types")
+		}
+		pairs = append(pairs, k, v)
+	}
+	return DictValue(len(pairs), func(i int) V { return pairs[i] }), nil
+}
+
+func (p *yqlParser) parseVariantValue() (V, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	v, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+	item, err := p.str()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(')'); err != nil {
+		return nil, err
+	}
+	variant, ok := t.(VariantType)
+	if !ok {
+		return nil, p.errorf("expected variant type, got %s", t)
+	}
+	i := -1
+	if variant.S.Empty() {
+		if x, err := strconv.ParseUint(item, 10, 32); err == nil {
+			i = int(x)
+		}
+	} else {
+		for j, f := range variant.S.Fields {
+			if f.Name == item {
+				i = j
+			}
+		}
+	}
+	if i < 0 {
+		return nil, p.errorf("no variant item %q in %s", item, t)
+	}
+	itemT, ok := variant.at(i)
+	if !ok {
+		return nil, p.errorf("no variant item %q in %s", item, t)
+	}
+	if !TypesEqual(itemT, v.(Value).t) {
+		return nil, p.errorf("unexpected type of variant item %q: %s", item, v.(Value).t)
+	}
+	return VariantValue(v, uint32(i), t), nil
+}
+
+func (p *yqlParser) parseDecimalValue() (V, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	s, err := p.str()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+	precision, err := p.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+	scale, err := p.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(')'); err != nil {
+		return nil, err
+	}
+	if err = checkDecimal(precision, scale); err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	v, err := ParseText(DecimalType{Precision: precision, Scale: scale}, s)
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,166 @@
+package value
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFormatYQL(t *testing.T) {
+	for _, test := range []struct {
+		value V
+		exp   string
+	}{
+		{value: BoolValue(true), exp: `true`},
+		{value: Int32Value(-42), exp: `Int32("-42")`},
+		{value: Uint64Value(42), exp: `Uint64("42")`},
+		{value: DoubleValue(1.5), exp: `Double("1.5")`},
+		{value: DateValue(1), exp: `Date("1970-01-02")`},
+		{value: DatetimeValue(60), exp: `Datetime("1970-01-01T00:01:00Z")`},
+		{value: TimestampValue(1500000), exp: `Timestamp("1970-01-01T00:00:01.500000Z")`},
+		{value: IntervalValue(0), exp: `Interval("PT0S")`},
+		{value: IntervalValue(-(90*60*1000000 + 1)), exp: `Interval("-PT1H30M0.000001S")`},
+		{value: IntervalValue(2 * 24 * 60 * 60 * 1000000), exp: `Interval("P2D")`},
+		{value: StringValue([]byte("a\"\x00\xff")), exp: `String("a\"\x00\xFF")`},
+		{value: UTF8Value("привет\n"), exp: `Utf8("привет\n")`},
+		{value: UUIDValue([16]byte{0: 0xab, 15: 0x01}), exp: `Uuid("ab000000-0000-0000-0000-000000000001")`},
+		{value: DecimalValue(DecimalType{22, 9}, [16]byte{14: 0x04, 15: 0xd2}), exp: `Decimal("0.000001234",22,9)`},
+		{value: VoidValue, exp: `Void()`},
+		{value: NullValue(TypeInt32), exp: `Nothing(Optional<Int32>)`},
+		{value: OptionalValue(OptionalValue(Int32Value(1))), exp: `Just(Just(Int32("1")))`},
+		{value: NullValue(OptionalType{T: TypeInt32}), exp: `Just(Nothing(Optional<Int32>))`},
+		{
+			value: Value{
+				t: OptionalType{T: OptionalType{T: TypeInt32}},
+				v: &Ydb.Value{Value: new(Ydb.Value_NullFlagValue)},
+			},
+			exp: `Nothing(Optional<Optional<Int32>>)`,
+		},
+		{
+			value: ListValue(2, func(i int) V { return Int32Value(int32(i)) }),
+			exp:   `AsList(Int32("0"),Int32("1"))`,
+		},
+		{value: Value{t: ListType{T: TypeUTF8}, v: ZeroValue(ListType{T: TypeUTF8}).v}, exp: `ListCreate(Utf8)`},
+		{
+			value: TupleValue(2, func(i int) V { return Int32Value(int32(i)) }),
+			exp:   `AsTuple(Int32("0"),Int32("1"))`,
+		},
+		{
+			value: StructValue(&StructValueProto{
+				Fields: []StructField{{"a", TypeBool}, {"b c", TypeBool}},
+				Values: []*Ydb.Value{BoolValue(true).v, BoolValue(false).v},
+			}),
+			exp: "AsStruct(true AS `a`,false AS `b c`)",
+		},
+		{
+			value: StructValue(&StructValueProto{
+				Fields: []StructField{{"select", TypeBool}, {"a`b\\c", TypeBool}},
+				Values: []*Ydb.Value{BoolValue(true).v, BoolValue(false).v},
+			}),
+			exp: "AsStruct(true AS `select`,false AS `a\\`b\\\\c`)",
+		},
+		{
+			value: DictValue(2, func(i int) V {
+				if i == 0 {
+					return UTF8Value("a")
+				}
+				return Int32Value(1)
+			}),
+			exp: `AsDict(AsTuple(Utf8("a"),Int32("1")))`,
+		},
+		{value: ZeroValue(DictType{Key: TypeUTF8, Payload: TypeInt32}), exp: `DictCreate(Utf8,Int32)`},
+		{
+			value: VariantValue(Int32Value(42), 1, VariantType{T: TupleType{Elems: []T{TypeUTF8, TypeInt32}}}),
+			exp:   `Variant(Int32("42"),"1",Variant<Utf8,Int32>)`,
+		},
+		{
+			value: VariantValue(Int32Value(42), 1, VariantType{S: StructType{Fields: []StructField{
+				{"foo", TypeUTF8},
+				{"bar", TypeInt32},
+			}}}),
+			exp: "Variant(Int32(\"42\"),\"bar\",Variant<`foo`:Utf8,`bar`:Int32>)",
+		},
+	} {
+		t.Run(test.exp, func(t *testing.T) {
+			act := FormatYQL(test.value)
+			if act != test.exp {
+				t.Fatalf("unexpected yql: %s, exp: %s", act, test.exp)
+			}
+			v, err := ParseYQL(act)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(v.ToYDB(), test.value.ToYDB()) {
+				t.Fatalf("unexpected parsed value: %s, exp: %s", v, test.value)
+			}
+		})
+	}
+}
+
+func TestParseText(t *testing.T) {
+	for _, test := range []struct {
+		t   T
+		s   string
+		exp V
+	}{
+		{t: TypeBool, s: "true", exp: BoolValue(true)},
+		{t: TypeInt8, s: "-8", exp: Int8Value(-8)},
+		{t: TypeFloat, s: "inf", exp: FloatValue(float32(math.Inf(1)))},
+		{t: TypeDatetime, s: "1970-01-01T03:00:00+03:00", exp: DatetimeValue(0)},
+		{t: TypeInterval, s: "P1W", exp: IntervalValue(7 * 24 * 60 * 60 * 1000000)},
+		{t: TypeInterval, s: "PT0.5S", exp: IntervalValue(500000)},
+		{t: TypeJSON, s: `{"a":1}`, exp: JSONValue(`{"a":1}`)},
+		{t: DecimalType{22, 9}, s: "1", exp: DecimalValue(DecimalType{22, 9}, BigEndianUint128(0, 1000000000))},
+	} {
+		act, err := ParseText(test.t, test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(act.ToYDB(), test.exp.ToYDB()) {
+			t.Errorf("unexpected value: %s, exp: %s", act, test.exp)
+		}
+	}
+	for _, test := range []struct {
+		t T
+		s string
+	}{
+		{t: TypeInt8, s: "128"},
+		{t: TypeUint32, s: "-1"},
+		{t: TypeDate, s: "2020-13-01"},
+		{t: TypeInterval, s: "PT1D"},
+		{t: TypeUUID, s: "ab"},
+		{t: ListType{T: TypeInt32}, s: "1"},
+		{t: DecimalType{Precision: 0, Scale: 0}, s: "1"},
+		{t: DecimalType{Precision: 40, Scale: 2}, s: "1.2"},
+	} {
+		if _, err := ParseText(test.t, test.s); err == nil {
+			t.Errorf("expected error for %q as %s", test.s, test.t)
+		}
+	}
+}
+
+func TestParseYQLError(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`Int32(42)`,
+		`Int32("42"`,
+		`AsList()`,
+		`AsList(Int32("1"),Utf8("2"))`,
+		`Nothing(Int32)`,
+		`AsStruct(true a)`,
+		`Variant(Int32("1"),"2",Variant<Utf8,Int32>)`,
+		`Variant(Int32("1"),"0",Variant<Utf8,Int32>)`,
+		`Variant(Int32("1"),"x",Variant<Int32>)`,
+		`Variant(Int32("1"),"zz",Variant<a:Int32>)`,
+		`Decimal("1.2",0,0)`,
+		`Decimal("1.2",40,2)`,
+		`Decimal("1.2",5,9)`,
+		`Utf8("a") Utf8("b")`,
+	} {
+		if _, err := ParseYQL(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
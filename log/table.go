@@ -315,6 +315,39 @@ func Table(log Logger, details trace.Details) trace.Table {
 					}
 				}
 			}
+			t.OnSessionTransaction = func(info trace.SessionTransactionStartInfo) func(trace.SessionTransactionDoneInfo) {
+				session := info.Session
+				tx := info.Tx
+				log.Tracef(`transaction start {id:"%s",status:"%s",tx:"%s",mode:"%s",lazy:%t}`,
+					session.ID(),
+					session.Status(),
+					tx.ID(),
+					info.Mode,
+					info.Lazy,
+				)
+				return func(info trace.SessionTransactionDoneInfo) {
+					if info.Error == nil {
+						log.Debugf(`transaction done {duration:"%s",id:"%s",status:"%s",tx:"%s",queries:%d,committed:%t}`,
+							info.Duration,
+							session.ID(),
+							session.Status(),
+							tx.ID(),
+							info.Queries,
+							info.Committed,
+						)
+					} else {
+						log.Errorf(`transaction failed {duration:"%s",id:"%s",status:"%s",tx:"%s",queries:%d,committed:%t,error:"%s"}`,
+							info.Duration,
+							session.ID(),
+							session.Status(),
+							tx.ID(),
+							info.Queries,
+							info.Committed,
+							info.Error,
+						)
+					}
+				}
+			}
 			t.OnSessionTransactionRollback = func(info trace.SessionTransactionRollbackStartInfo) func(trace.SessionTransactionRollbackDoneInfo) {
 				session := info.Session
 				tx := info.Tx
@@ -360,6 +393,56 @@ func Table(log Logger, details trace.Details) trace.Table {
 					)
 				}
 			}
+			t.OnPoolWarmUp = func(info trace.PoolWarmUpStartInfo) func(trace.PoolWarmUpDoneInfo) {
+				log.Debugf(`warm up start {idle:%d,min:%d}`,
+					info.Idle,
+					info.MinIdleSize,
+				)
+				start := time.Now()
+				return func(info trace.PoolWarmUpDoneInfo) {
+					if info.Error == nil {
+						log.Debugf(`warm up done {latency:"%s",created:%d,idle:%d}`,
+							time.Since(start),
+							info.Created,
+							info.Idle,
+						)
+					} else {
+						log.Warnf(`warm up failed {latency:"%s",created:%d,idle:%d,error:"%s"}`,
+							time.Since(start),
+							info.Created,
+							info.Idle,
+							info.Error,
+						)
+					}
+				}
+			}
+			t.OnPoolNodeShutdown = func(info trace.PoolNodeShutdownStartInfo) func(trace.PoolNodeShutdownDoneInfo) {
+				address := info.Address
+				log.Infof(`node shutdown start {address:"%s"}`,
+					address,
+				)
+				start := time.Now()
+				return func(info trace.PoolNodeShutdownDoneInfo) {
+					if info.Error == nil {
+						log.Infof(`node shutdown done {latency:"%s",address:"%s",evicted:%d,marked:%d,created:%d}`,
+							time.Since(start),
+							address,
+							info.Evicted,
+							info.Marked,
+							info.Created,
+						)
+					} else {
+						log.Warnf(`node shutdown failed {latency:"%s",address:"%s",evicted:%d,marked:%d,created:%d,error:"%s"}`,
+							time.Since(start),
+							address,
+							info.Evicted,
+							info.Marked,
+							info.Created,
+							info.Error,
+						)
+					}
+				}
+			}
 			t.OnPoolClose = func(info trace.PoolCloseStartInfo) func(trace.PoolCloseDoneInfo) {
 				log.Infof(`close start`)
 				start := time.Now()
@@ -462,20 +545,31 @@ func Table(log Logger, details trace.Details) trace.Table {
 				}
 			}
 			t.OnPoolWait = func(info trace.PoolWaitStartInfo) func(trace.PoolWaitDoneInfo) {
-				log.Tracef(`wait start`)
+				log.Tracef(`wait start {priority:%d,position:%d}`,
+					info.Priority,
+					info.Position,
+				)
 				start := time.Now()
 				return func(info trace.PoolWaitDoneInfo) {
-					if info.Error == nil {
+					switch {
+					case info.Error != nil:
+						log.Warnf(`wait failed {latency:"%s",queueTime:"%s",error:"%s"}`,
+							time.Since(start),
+							info.QueueTime,
+							info.Error,
+						)
+					case info.Session != nil:
 						session := info.Session
-						log.Tracef(`wait done {latency:"%s",id:"%s",status:"%s"}`,
+						log.Tracef(`wait done {latency:"%s",queueTime:"%s",id:"%s",status:"%s"}`,
 							time.Since(start),
+							info.QueueTime,
 							session.ID(),
 							session.Status(),
 						)
-					} else {
-						log.Warnf(`wait failed {latency:"%s",error:"%s"}`,
+					default:
+						log.Tracef(`wait done without session {latency:"%s",queueTime:"%s"}`,
 							time.Since(start),
-							info.Error,
+							info.QueueTime,
 						)
 					}
 				}
@@ -0,0 +1,342 @@
+// Package migration implements ordered schema migrations on top of table.Client.
+//
+// Applied versions are stored in a service table inside the given directory.
+// Concurrent runners are guarded by a lock row which is acquired and released
+// within serializable read-write transactions. The lock is a lease which
+// expires after lock TTL and is renewed while migrations are applied, so
+// a lock of crashed runner does not block others forever.
+//
+//     m, err := migration.New(db.Table(), db.Name(), []migration.Migration{
+//         {
+//             Version:     1,
+//             Description: "create users",
+//             Up:          migration.Query(`CREATE TABLE users (id Uint64, name Utf8, PRIMARY KEY (id));`),
+//             Down:        migration.Query(`DROP TABLE users;`),
+//         },
+//     })
+//     if err != nil {
+//         // handle error
+//     }
+//     if err = m.Up(ctx); err != nil {
+//         // handle error
+//     }
+//
+// Note that schema changes are not transactional: if a step fails in the middle
+// then the version is not recorded and the step must be safe to run again.
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/deadline"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+var (
+	// ErrLocked returned when migrations are being applied by another runner.
+	ErrLocked = errors.New("ydb: migration: locked by another runner")
+
+	// ErrNoDownStep returned when migration to revert has no Down step.
+	ErrNoDownStep = errors.New("ydb: migration: no down step")
+
+	// ErrUnknownVersion returned when applied version is absent in the list of migrations.
+	ErrUnknownVersion = errors.New("ydb: migration: unknown version")
+
+	// ErrNothingToRevert returned from Down and Redo when there are no applied migrations.
+	ErrNothingToRevert = errors.New("ydb: migration: nothing to revert")
+)
+
+const (
+	// DefaultTableName is a name of service table with applied versions.
+	DefaultTableName = "schema_migrations"
+
+	// DefaultLockTTL is a time after which a lock of crashed runner is considered as stale.
+	DefaultLockTTL = 5 * time.Minute
+
+	// unlockTimeout limits release of the lock, which is done regardless of
+	// cancellation of the context of migrations.
+	unlockTimeout = 30 * time.Second
+)
+
+// Step is a single direction of migration.
+type Step func(ctx context.Context, s table.Session) error
+
+// Query returns Step which executes given scheme query.
+// Use PRAGMA TablePathPrefix in query text for relative table names.
+func Query(yql string) Step {
+	return func(ctx context.Context, s table.Session) error {
+		return s.ExecuteSchemeQuery(ctx, yql)
+	}
+}
+
+// Migration describes versioned change of schema.
+type Migration struct {
+	// Version must be unique and greater than zero.
+	Version uint64
+
+	Description string
+
+	Up   Step
+	Down Step
+}
+
+// State describes state of the migration.
+type State struct {
+	Migration
+
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Option func(m *Migrator)
+
+// WithTableName overrides name of the service table.
+// Lock table has the same name with "_lock" suffix.
+func WithTableName(name string) Option {
+	return func(m *Migrator) {
+		m.tableName = name
+	}
+}
+
+// WithLockTTL overrides time after which the lock of crashed runner is ignored.
+// The lock of running migrations is renewed every third of ttl.
+func WithLockTTL(ttl time.Duration) Option {
+	return func(m *Migrator) {
+		m.lockTTL = ttl
+	}
+}
+
+// WithOwner sets identifier of the runner stored in the lock row.
+func WithOwner(owner string) Option {
+	return func(m *Migrator) {
+		m.owner = owner
+	}
+}
+
+// Migrator applies and reverts migrations.
+type Migrator struct {
+	c          table.Client
+	directory  string
+	tableName  string
+	lockTTL    time.Duration
+	owner      string
+	migrations []Migration
+}
+
+// New makes Migrator for given migrations.
+// Service tables are created in directory on first use.
+func New(c table.Client, directory string, migrations []Migration, opts ...Option) (*Migrator, error) {
+	m := &Migrator{
+		c:          c,
+		directory:  directory,
+		tableName:  DefaultTableName,
+		lockTTL:    DefaultLockTTL,
+		owner:      fmt.Sprintf("migrator-%d", time.Now().UnixNano()),
+		migrations: make([]Migration, len(migrations)),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	copy(m.migrations, migrations)
+	sort.Slice(m.migrations, func(i, j int) bool {
+		return m.migrations[i].Version < m.migrations[j].Version
+	})
+	for i, migration := range m.migrations {
+		if migration.Version == 0 {
+			return nil, fmt.Errorf("ydb: migration: zero version of %q", migration.Description)
+		}
+		if migration.Up == nil {
+			return nil, fmt.Errorf("ydb: migration: no up step in version %d", migration.Version)
+		}
+		if i > 0 && m.migrations[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("ydb: migration: duplicate version %d", migration.Version)
+		}
+	}
+	return m, nil
+}
+
+// Status returns states of all known migrations ordered by version.
+func (m *Migrator) Status(ctx context.Context) (states []State, err error) {
+	if err = m.init(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = unknown(m.migrations, applied); err != nil {
+		return nil, err
+	}
+	states = make([]State, len(m.migrations))
+	for i, migration := range m.migrations {
+		at, ok := applied[migration.Version]
+		states[i] = State{
+			Migration: migration,
+			Applied:   ok,
+			AppliedAt: at,
+		}
+	}
+	return states, nil
+}
+
+// Up applies all pending migrations in order of versions.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(ctx context.Context, applied map[uint64]time.Time) error {
+		for _, migration := range pending(m.migrations, applied) {
+			if err := m.up(ctx, migration); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down reverts last applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.locked(ctx, func(ctx context.Context, applied map[uint64]time.Time) error {
+		migration, err := last(m.migrations, applied)
+		if err != nil {
+			return err
+		}
+		return m.down(ctx, migration)
+	})
+}
+
+// Redo reverts last applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) error {
+	return m.locked(ctx, func(ctx context.Context, applied map[uint64]time.Time) error {
+		migration, err := last(m.migrations, applied)
+		if err != nil {
+			return err
+		}
+		if err = m.down(ctx, migration); err != nil {
+			return err
+		}
+		return m.up(ctx, migration)
+	})
+}
+
+func (m *Migrator) up(ctx context.Context, migration Migration) error {
+	err := m.c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		return migration.Up(ctx, s)
+	})
+	if err != nil {
+		return fmt.Errorf("ydb: migration: up %d failed: %w", migration.Version, err)
+	}
+	return m.markApplied(ctx, migration)
+}
+
+func (m *Migrator) down(ctx context.Context, migration Migration) error {
+	if migration.Down == nil {
+		return fmt.Errorf("ydb: migration: down %d failed: %w", migration.Version, ErrNoDownStep)
+	}
+	err := m.c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		return migration.Down(ctx, s)
+	})
+	if err != nil {
+		return fmt.Errorf("ydb: migration: down %d failed: %w", migration.Version, err)
+	}
+	return m.markReverted(ctx, migration)
+}
+
+func (m *Migrator) locked(ctx context.Context, f func(context.Context, map[uint64]time.Time) error) (err error) {
+	if err = m.init(ctx); err != nil {
+		return err
+	}
+	if err = m.lock(ctx); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	renewed := make(chan error, 1)
+	go func() {
+		renewed <- m.renew(ctx, cancel)
+	}()
+	defer func() {
+		cancel()
+		if renewErr := <-renewed; renewErr != nil {
+			// Lost lock is the cause of cancellation of migrations.
+			err = renewErr
+		}
+		unlockCtx, unlockCancel := context.WithTimeout(deadline.ContextWithoutDeadline(ctx), unlockTimeout)
+		defer unlockCancel()
+		if unlockErr := m.unlock(unlockCtx); err == nil {
+			err = unlockErr
+		}
+	}()
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = unknown(m.migrations, applied); err != nil {
+		return err
+	}
+	return f(ctx, applied)
+}
+
+// renew prolongs the lock until ctx is done. If the lock is taken by another
+// runner, renew cancels migrations with cancel and returns ErrLocked.
+// Other errors are ignored as the lock is renewed again on next tick before
+// it expires.
+func (m *Migrator) renew(ctx context.Context, cancel context.CancelFunc) error {
+	interval := m.lockTTL / 3
+	if interval <= 0 {
+		interval = time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.lock(ctx); errors.Is(err, ErrLocked) {
+				cancel()
+				return fmt.Errorf("ydb: migration: lock lost: %w", err)
+			}
+		}
+	}
+}
+
+// pending returns not applied migrations in order of versions.
+func pending(migrations []Migration, applied map[uint64]time.Time) (p []Migration) {
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			p = append(p, migration)
+		}
+	}
+	return p
+}
+
+// last returns applied migration with the greatest version.
+func last(migrations []Migration, applied map[uint64]time.Time) (Migration, error) {
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if _, ok := applied[migrations[i].Version]; ok {
+			return migrations[i], nil
+		}
+	}
+	return Migration{}, ErrNothingToRevert
+}
+
+// unknown returns applied versions which are absent in migrations.
+func unknown(migrations []Migration, applied map[uint64]time.Time) (versions []uint64, err error) {
+	known := make(map[uint64]struct{}, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = struct{}{}
+	}
+	for version := range applied {
+		if _, ok := known[version]; !ok {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+	return versions, fmt.Errorf("%w: %v", ErrUnknownVersion, versions)
+}
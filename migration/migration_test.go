@@ -0,0 +1,114 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+func noop(context.Context, table.Session) error { return nil }
+
+func versions(migrations []Migration) (vs []uint64) {
+	for _, m := range migrations {
+		vs = append(vs, m.Version)
+	}
+	return vs
+}
+
+func TestNew(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		migrations []Migration
+		exp        []uint64
+		err        bool
+	}{
+		{
+			name: "sorted",
+			migrations: []Migration{
+				{Version: 3, Up: noop},
+				{Version: 1, Up: noop},
+				{Version: 2, Up: noop},
+			},
+			exp: []uint64{1, 2, 3},
+		},
+		{
+			name: "duplicate",
+			migrations: []Migration{
+				{Version: 1, Up: noop},
+				{Version: 1, Up: noop},
+			},
+			err: true,
+		},
+		{
+			name: "zero version",
+			migrations: []Migration{
+				{Version: 0, Up: noop},
+			},
+			err: true,
+		},
+		{
+			name: "no up step",
+			migrations: []Migration{
+				{Version: 1},
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := New(nil, "/local", test.migrations)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if act := versions(m.migrations); !reflect.DeepEqual(act, test.exp) {
+				t.Fatalf("unexpected versions: %v, exp: %v", act, test.exp)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Up: noop},
+		{Version: 2, Up: noop},
+		{Version: 3, Up: noop},
+	}
+	applied := map[uint64]time.Time{
+		1: time.Now(),
+		2: time.Now(),
+	}
+	if act := versions(pending(migrations, applied)); !reflect.DeepEqual(act, []uint64{3}) {
+		t.Fatalf("unexpected pending: %v", act)
+	}
+	m, err := last(migrations, applied)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Version != 2 {
+		t.Fatalf("unexpected last: %d", m.Version)
+	}
+	if _, err = last(migrations, nil); !errors.Is(err, ErrNothingToRevert) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vs, err := unknown(migrations, applied); err != nil || vs != nil {
+		t.Fatalf("unexpected unknown: %v, %v", vs, err)
+	}
+	applied[5] = time.Now()
+	applied[4] = time.Now()
+	vs, err := unknown(migrations, applied)
+	if !errors.Is(err, ErrUnknownVersion) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(vs, []uint64{4, 5}) {
+		t.Fatalf("unexpected unknown: %v", vs)
+	}
+}
@@ -0,0 +1,216 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/errors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+const lockID = uint32(0)
+
+func (m *Migrator) lockTableName() string {
+	return m.tableName + "_lock"
+}
+
+func (m *Migrator) prefix() string {
+	return fmt.Sprintf("PRAGMA TablePathPrefix(\"%s\");\n", m.directory)
+}
+
+// init creates service tables if not exists.
+func (m *Migrator) init(ctx context.Context) error {
+	return m.c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		if err := createIfNotExists(ctx, s, path.Join(m.directory, m.tableName),
+			options.WithColumn("version", types.Optional(types.TypeUint64)),
+			options.WithColumn("description", types.Optional(types.TypeUTF8)),
+			options.WithColumn("applied_at", types.Optional(types.TypeTimestamp)),
+			options.WithPrimaryKeyColumn("version"),
+		); err != nil {
+			return err
+		}
+		return createIfNotExists(ctx, s, path.Join(m.directory, m.lockTableName()),
+			options.WithColumn("id", types.Optional(types.TypeUint32)),
+			options.WithColumn("owner", types.Optional(types.TypeUTF8)),
+			options.WithColumn("expires_at", types.Optional(types.TypeTimestamp)),
+			options.WithPrimaryKeyColumn("id"),
+		)
+	}, table.WithIdempotent())
+}
+
+func createIfNotExists(ctx context.Context, s table.Session, path string, opts ...options.CreateTableOption) error {
+	_, err := s.DescribeTable(ctx, path)
+	if err == nil {
+		return nil
+	}
+	var o *errors.OpError
+	if !errors.As(err, &o) || o.Reason != errors.StatusSchemeError {
+		return err
+	}
+	return s.CreateTable(ctx, path, opts...)
+}
+
+// lock acquires lock row or returns ErrLocked if it is held by another runner.
+func (m *Migrator) lock(ctx context.Context) error {
+	query := m.prefix() + fmt.Sprintf(`
+		DECLARE $id AS Uint32;
+		SELECT owner, expires_at > CurrentUtcTimestamp() AS held
+		FROM %s
+		WHERE id = $id;`,
+		m.lockTableName(),
+	)
+	upsert := m.prefix() + fmt.Sprintf(`
+		DECLARE $id AS Uint32;
+		DECLARE $owner AS Utf8;
+		DECLARE $ttl AS Interval;
+		UPSERT INTO %s (id, owner, expires_at)
+		VALUES ($id, $owner, CurrentUtcTimestamp() + $ttl);`,
+		m.lockTableName(),
+	)
+	return m.c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		tx, res, err := s.Execute(ctx,
+			table.TxControl(table.BeginTx(table.WithSerializableReadWrite())),
+			query,
+			table.NewQueryParameters(
+				table.ValueParam("$id", types.Uint32Value(lockID)),
+			),
+		)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = res.Close()
+		}()
+		var (
+			owner string
+			held  bool
+		)
+		if res.NextResultSet(ctx) && res.NextRow() {
+			if err = res.ScanWithDefaults(&owner, &held); err != nil {
+				_ = tx.Rollback(ctx)
+				return err
+			}
+		}
+		if err = res.Err(); err != nil {
+			_ = tx.Rollback(ctx)
+			return err
+		}
+		if held && owner != m.owner {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("%w: %s", ErrLocked, owner)
+		}
+		if _, err = tx.Execute(ctx, upsert, table.NewQueryParameters(
+			table.ValueParam("$id", types.Uint32Value(lockID)),
+			table.ValueParam("$owner", types.UTF8Value(m.owner)),
+			table.ValueParam("$ttl", types.IntervalValueFromDuration(m.lockTTL)),
+		)); err != nil {
+			_ = tx.Rollback(ctx)
+			return err
+		}
+		_, err = tx.CommitTx(ctx)
+		return err
+	})
+}
+
+// unlock releases lock row if it is held by this runner.
+func (m *Migrator) unlock(ctx context.Context) error {
+	query := m.prefix() + fmt.Sprintf(`
+		DECLARE $id AS Uint32;
+		DECLARE $owner AS Utf8;
+		DELETE FROM %s
+		WHERE id = $id AND owner = $owner;`,
+		m.lockTableName(),
+	)
+	return m.c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		_, _, err := s.Execute(ctx,
+			table.TxControl(table.BeginTx(table.WithSerializableReadWrite()), table.CommitTx()),
+			query,
+			table.NewQueryParameters(
+				table.ValueParam("$id", types.Uint32Value(lockID)),
+				table.ValueParam("$owner", types.UTF8Value(m.owner)),
+			),
+		)
+		return err
+	}, table.WithIdempotent())
+}
+
+// applied returns applied versions with time of applying.
+func (m *Migrator) applied(ctx context.Context) (applied map[uint64]time.Time, err error) {
+	query := m.prefix() + fmt.Sprintf(`
+		SELECT version, applied_at
+		FROM %s;`,
+		m.tableName,
+	)
+	err = m.c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		_, res, err := s.Execute(ctx,
+			table.TxControl(table.BeginTx(table.WithSerializableReadWrite()), table.CommitTx()),
+			query,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = res.Close()
+		}()
+		applied = make(map[uint64]time.Time)
+		for res.NextResultSet(ctx) {
+			for res.NextRow() {
+				var (
+					version   uint64
+					appliedAt time.Time
+				)
+				if err = res.ScanWithDefaults(&version, &appliedAt); err != nil {
+					return err
+				}
+				applied[version] = appliedAt
+			}
+		}
+		return res.Err()
+	}, table.WithIdempotent())
+	return applied, err
+}
+
+func (m *Migrator) markApplied(ctx context.Context, migration Migration) error {
+	query := m.prefix() + fmt.Sprintf(`
+		DECLARE $version AS Uint64;
+		DECLARE $description AS Utf8;
+		UPSERT INTO %s (version, description, applied_at)
+		VALUES ($version, $description, CurrentUtcTimestamp());`,
+		m.tableName,
+	)
+	return m.c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		_, _, err := s.Execute(ctx,
+			table.TxControl(table.BeginTx(table.WithSerializableReadWrite()), table.CommitTx()),
+			query,
+			table.NewQueryParameters(
+				table.ValueParam("$version", types.Uint64Value(migration.Version)),
+				table.ValueParam("$description", types.UTF8Value(migration.Description)),
+			),
+		)
+		return err
+	}, table.WithIdempotent())
+}
+
+func (m *Migrator) markReverted(ctx context.Context, migration Migration) error {
+	query := m.prefix() + fmt.Sprintf(`
+		DECLARE $version AS Uint64;
+		DELETE FROM %s
+		WHERE version = $version;`,
+		m.tableName,
+	)
+	return m.c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		_, _, err := s.Execute(ctx,
+			table.TxControl(table.BeginTx(table.WithSerializableReadWrite()), table.CommitTx()),
+			query,
+			table.NewQueryParameters(
+				table.ValueParam("$version", types.Uint64Value(migration.Version)),
+			),
+		)
+		return err
+	}, table.WithIdempotent())
+}
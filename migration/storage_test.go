@@ -0,0 +1,349 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	ydbErrors "github.com/ydb-platform/ydb-go-sdk/v3/internal/errors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/resultset"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type lockRow struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// store is an in-memory stub of service tables which interprets queries of
+// the migrator.
+type store struct {
+	table.Session
+
+	mu      sync.Mutex
+	now     func() time.Time
+	tables  map[string]bool
+	lock    *lockRow
+	applied map[uint64]time.Time
+}
+
+func newStore() *store {
+	return &store{
+		now:     time.Now,
+		tables:  make(map[string]bool),
+		applied: make(map[uint64]time.Time),
+	}
+}
+
+func (s *store) lockOwner() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lock == nil {
+		return ""
+	}
+	return s.lock.owner
+}
+
+func (s *store) DescribeTable(_ context.Context, path string, _ ...options.DescribeTableOption) (options.Description, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.tables[path] {
+		return options.Description{}, ydbErrors.NewOpError(ydbErrors.WithOEReason(ydbErrors.StatusSchemeError))
+	}
+	return options.Description{}, nil
+}
+
+func (s *store) CreateTable(_ context.Context, path string, _ ...options.CreateTableOption) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables[path] = true
+	return nil
+}
+
+func (s *store) Execute(
+	ctx context.Context, _ *table.TransactionControl, query string, params *table.QueryParameters,
+	_ ...options.ExecuteDataQueryOption,
+) (table.Transaction, resultset.Result, error) {
+	res, err := s.execute(ctx, query, params)
+	return &tx{s: s}, res, err
+}
+
+func (s *store) execute(ctx context.Context, query string, params *table.QueryParameters) (resultset.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p := make(map[string]types.Value)
+	params.Each(func(name string, v types.Value) {
+		p[name] = v
+	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	switch {
+	case strings.Contains(query, "SELECT owner"):
+		set := &Ydb.ResultSet{
+			Columns: []*Ydb.Column{
+				{Name: "owner", Type: value.TypeToYDB(types.Optional(types.TypeUTF8))},
+				{Name: "held", Type: value.TypeToYDB(types.Optional(types.TypeBool))},
+			},
+		}
+		if s.lock != nil {
+			set.Rows = append(set.Rows, &Ydb.Value{Items: []*Ydb.Value{
+				types.OptionalValue(types.UTF8Value(s.lock.owner)).ToYDB().Value,
+				types.OptionalValue(types.BoolValue(s.lock.expiresAt.After(now))).ToYDB().Value,
+			}})
+		}
+		return &scanner.Result{Sets: []*Ydb.ResultSet{set}}, nil
+	case strings.Contains(query, "UPSERT INTO schema_migrations_lock"):
+		owner, _ := types.AsUTF8(p["$owner"])
+		ttl, _ := types.AsInterval(p["$ttl"])
+		s.lock = &lockRow{owner: owner, expiresAt: now.Add(ttl)}
+	case strings.Contains(query, "DELETE FROM schema_migrations_lock"):
+		owner, _ := types.AsUTF8(p["$owner"])
+		if s.lock != nil && s.lock.owner == owner {
+			s.lock = nil
+		}
+	case strings.Contains(query, "SELECT version"):
+		set := &Ydb.ResultSet{
+			Columns: []*Ydb.Column{
+				{Name: "version", Type: value.TypeToYDB(types.Optional(types.TypeUint64))},
+				{Name: "applied_at", Type: value.TypeToYDB(types.Optional(types.TypeTimestamp))},
+			},
+		}
+		for version, at := range s.applied {
+			set.Rows = append(set.Rows, &Ydb.Value{Items: []*Ydb.Value{
+				types.OptionalValue(types.Uint64Value(version)).ToYDB().Value,
+				types.OptionalValue(types.TimestampValueFromTime(at)).ToYDB().Value,
+			}})
+		}
+		return &scanner.Result{Sets: []*Ydb.ResultSet{set}}, nil
+	case strings.Contains(query, "UPSERT INTO schema_migrations"):
+		version, _ := types.AsUint64(p["$version"])
+		s.applied[version] = now
+	case strings.Contains(query, "DELETE FROM schema_migrations"):
+		version, _ := types.AsUint64(p["$version"])
+		delete(s.applied, version)
+	default:
+		return nil, errors.New("unexpected query: " + query)
+	}
+	return &scanner.Result{}, nil
+}
+
+type tx struct {
+	table.Transaction
+
+	s *store
+}
+
+func (x *tx) Execute(
+	ctx context.Context, query string, params *table.QueryParameters, _ ...options.ExecuteDataQueryOption,
+) (resultset.Result, error) {
+	return x.s.execute(ctx, query, params)
+}
+
+func (x *tx) CommitTx(ctx context.Context, _ ...options.CommitTransactionOption) (resultset.Result, error) {
+	return &scanner.Result{}, ctx.Err()
+}
+
+func (x *tx) Rollback(context.Context) error {
+	return nil
+}
+
+type client struct {
+	table.Client
+
+	s *store
+}
+
+func (c *client) Do(ctx context.Context, op table.Operation, _ ...table.Option) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return op(ctx, c.s)
+}
+
+func newMigrator(t *testing.T, s *store, owner string, migrations []Migration, opts ...Option) *Migrator {
+	t.Helper()
+	m, err := New(&client{s: s}, "/local", migrations, append(opts, WithOwner(owner))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	s := newStore()
+	var steps []string
+	step := func(name string) Step {
+		return func(context.Context, table.Session) error {
+			steps = append(steps, name)
+			return nil
+		}
+	}
+	m := newMigrator(t, s, "a", []Migration{
+		{Version: 1, Up: step("up 1"), Down: step("down 1")},
+		{Version: 2, Up: step("up 2"), Down: step("down 2")},
+	})
+	states, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 2 || states[0].Applied || states[1].Applied {
+		t.Fatalf("unexpected states: %+v", states)
+	}
+	if !s.tables["/local/schema_migrations"] || !s.tables["/local/schema_migrations_lock"] {
+		t.Fatalf("service tables are not created: %v", s.tables)
+	}
+	if err = m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Down(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if exp := "up 1,up 2,down 2"; strings.Join(steps, ",") != exp {
+		t.Fatalf("unexpected steps: %v, exp: %s", steps, exp)
+	}
+	if states, err = m.Status(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !states[0].Applied || states[1].Applied {
+		t.Fatalf("unexpected states: %+v", states)
+	}
+	if owner := s.lockOwner(); owner != "" {
+		t.Fatalf("lock is not released: %s", owner)
+	}
+}
+
+func TestLock(t *testing.T) {
+	ctx := context.Background()
+	s := newStore()
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	a := newMigrator(t, s, "a", nil)
+	b := newMigrator(t, s, "b", nil)
+	if err := a.init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.lock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.lock(ctx); !errors.Is(err, ErrLocked) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Owner prolongs its lock.
+	if err := a.lock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// Unlock of another owner does nothing.
+	if err := b.unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if owner := s.lockOwner(); owner != "a" {
+		t.Fatalf("unexpected lock owner: %q", owner)
+	}
+	if err := a.unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.lock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// Stale lock is ignored.
+	now = now.Add(DefaultLockTTL + time.Second)
+	if err := a.lock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if owner := s.lockOwner(); owner != "a" {
+		t.Fatalf("unexpected lock owner: %q", owner)
+	}
+}
+
+func TestUnlockCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newStore()
+	m := newMigrator(t, s, "a", []Migration{
+		{
+			Version: 1,
+			Up: func(context.Context, table.Session) error {
+				cancel()
+				return nil
+			},
+		},
+	})
+	if err := m.Up(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner := s.lockOwner(); owner != "" {
+		t.Fatalf("lock is not released: %s", owner)
+	}
+}
+
+func TestLockRenewal(t *testing.T) {
+	ctx := context.Background()
+	s := newStore()
+	var (
+		ttl     = 50 * time.Millisecond
+		started = make(chan struct{})
+		proceed = make(chan struct{})
+	)
+	a := newMigrator(t, s, "a", []Migration{
+		{
+			Version: 1,
+			Up: func(context.Context, table.Session) error {
+				close(started)
+				<-proceed
+				return nil
+			},
+		},
+	}, WithLockTTL(ttl))
+	b := newMigrator(t, s, "b", nil, WithLockTTL(ttl))
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Up(ctx)
+	}()
+	<-started
+	time.Sleep(3 * ttl)
+	if err := b.Up(ctx); !errors.Is(err, ErrLocked) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(proceed)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if owner := s.lockOwner(); owner != "" {
+		t.Fatalf("lock is not released: %s", owner)
+	}
+}
+
+func TestLockLost(t *testing.T) {
+	ctx := context.Background()
+	s := newStore()
+	a := newMigrator(t, s, "a", []Migration{
+		{
+			Version: 1,
+			Up: func(ctx context.Context, _ table.Session) error {
+				s.mu.Lock()
+				s.lock = &lockRow{owner: "b", expiresAt: time.Now().Add(time.Hour)}
+				s.mu.Unlock()
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+	}, WithLockTTL(30*time.Millisecond))
+	if err := a.Up(ctx); !errors.Is(err, ErrLocked) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner := s.lockOwner(); owner != "b" {
+		t.Fatalf("lock of another runner is released: %q", owner)
+	}
+}
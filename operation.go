@@ -0,0 +1,60 @@
+package ydb
+
+import (
+	"context"
+	"sync"
+
+	internal "github.com/ydb-platform/ydb-go-sdk/v3/internal/operation"
+	"github.com/ydb-platform/ydb-go-sdk/v3/operation"
+)
+
+type lazyOperation struct {
+	db     DB
+	client operation.Client
+	m      sync.Mutex
+}
+
+func (o *lazyOperation) Get(ctx context.Context, id string) (op operation.Operation, err error) {
+	o.init()
+	return o.client.Get(ctx, id)
+}
+
+func (o *lazyOperation) Cancel(ctx context.Context, id string) (err error) {
+	o.init()
+	return o.client.Cancel(ctx, id)
+}
+
+func (o *lazyOperation) Forget(ctx context.Context, id string) (err error) {
+	o.init()
+	return o.client.Forget(ctx, id)
+}
+
+func (o *lazyOperation) List(ctx context.Context, kind string) (ops []operation.Operation, err error) {
+	o.init()
+	return o.client.List(ctx, kind)
+}
+
+func (o *lazyOperation) Wait(ctx context.Context, id string) (op operation.Operation, err error) {
+	o.init()
+	return o.client.Wait(ctx, id)
+}
+
+func (o *lazyOperation) Close(ctx context.Context) error {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.client == nil {
+		return nil
+	}
+	defer func() {
+		o.client = nil
+	}()
+	return o.client.Close(ctx)
+}
+
+func (o *lazyOperation) init() {
+	o.m.Lock()
+	if o.client == nil {
+		o.client = internal.New(o.db)
+	}
+	o.m.Unlock()
+}
@@ -0,0 +1,59 @@
+package operation
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	ctxTimeoutKey     struct{}
+	ctxCancelAfterKey struct{}
+)
+
+// WithTimeout returns a copy of parent context with operation timeout of YDB
+// requests made with the context (table, scheme, coordination and rate limiter
+// calls). After the timeout server cancels operation if it is possible and
+// returns TIMEOUT status regardless of the cancellation result.
+//
+// Operation timeout of the context overrides the driver-wide one
+// (see config.WithOperationTimeout). If parent context already has smaller
+// operation timeout, parent context is returned.
+//
+// Requests of synchronous operations (such as data queries) send the smaller of
+// operation timeout and time until the context deadline, so server does not
+// continue the operation which result will not be awaited by client. Asynchronous
+// operations are not limited by context deadline.
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	if cur, ok := ContextTimeout(ctx); ok && d >= cur {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxTimeoutKey{}, d)
+}
+
+// ContextTimeout returns operation timeout set with WithTimeout.
+func ContextTimeout(ctx context.Context) (d time.Duration, ok bool) {
+	d, ok = ctx.Value(ctxTimeoutKey{}).(time.Duration)
+	return
+}
+
+// WithCancelAfter returns a copy of parent context with operation cancel after
+// parameter of YDB requests made with the context. After the time server
+// cancels operation and returns CANCELLED status if the cancellation succeeds.
+//
+// Cancel after parameter of the context overrides the driver-wide one
+// (see config.WithOperationCancelAfter) and is not limited by context deadline.
+// If parent context already has smaller cancel after parameter, parent
+// context is returned.
+func WithCancelAfter(ctx context.Context, d time.Duration) context.Context {
+	if cur, ok := ContextCancelAfter(ctx); ok && d >= cur {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxCancelAfterKey{}, d)
+}
+
+// ContextCancelAfter returns operation cancel after parameter set with
+// WithCancelAfter.
+func ContextCancelAfter(ctx context.Context) (d time.Duration, ok bool) {
+	d, ok = ctx.Value(ctxCancelAfterKey{}).(time.Duration)
+	return
+}
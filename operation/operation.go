@@ -0,0 +1,141 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/errors"
+)
+
+// Kinds of operations which can be listed with Client.List.
+const (
+	KindIndexBuild = "buildindex"
+	KindExport     = "export"
+	KindImport     = "import"
+)
+
+type Client interface {
+	// Get returns current state of operation with given id.
+	Get(ctx context.Context, id string) (op Operation, err error)
+	// Cancel starts cancellation of operation with given id.
+	Cancel(ctx context.Context, id string) (err error)
+	// Forget forgets about operation with given id on server side.
+	Forget(ctx context.Context, id string) (err error)
+	// List returns all operations of given kind.
+	List(ctx context.Context, kind string) (ops []Operation, err error)
+	// Wait polls operation with given id with backoff until it becomes ready.
+	// Wait returns error if operation completed unsuccessfully.
+	Wait(ctx context.Context, id string) (op Operation, err error)
+
+	Close(ctx context.Context) error
+}
+
+// Operation is a state of YDB long-running operation.
+type Operation struct {
+	ID    string
+	Ready bool
+
+	// Err is not nil if operation is ready and completed unsuccessfully.
+	Err error
+
+	metadata *anypb.Any
+}
+
+// New makes Operation from its YDB representation.
+func New(op *Ydb_Operations.Operation) Operation {
+	o := Operation{
+		ID:       op.GetId(),
+		Ready:    op.GetReady(),
+		metadata: op.GetMetadata(),
+	}
+	if o.Ready && op.GetStatus() != Ydb.StatusIds_SUCCESS {
+		o.Err = errors.NewOpError(errors.WithOEOperation(op))
+	}
+	return o
+}
+
+type IndexBuildState byte
+
+const (
+	IndexBuildStateUnknown IndexBuildState = iota
+	IndexBuildStatePreparing
+	IndexBuildStateTransferingData
+	IndexBuildStateApplying
+	IndexBuildStateDone
+	IndexBuildStateCancellation
+	IndexBuildStateCancelled
+	IndexBuildStateRejection
+	IndexBuildStateRejected
+)
+
+func (s IndexBuildState) String() string {
+	switch s {
+	case IndexBuildStatePreparing:
+		return "preparing"
+	case IndexBuildStateTransferingData:
+		return "transfering data"
+	case IndexBuildStateApplying:
+		return "applying"
+	case IndexBuildStateDone:
+		return "done"
+	case IndexBuildStateCancellation:
+		return "cancellation"
+	case IndexBuildStateCancelled:
+		return "cancelled"
+	case IndexBuildStateRejection:
+		return "rejection"
+	case IndexBuildStateRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+func indexBuildState(s Ydb_Table.IndexBuildState_State) IndexBuildState {
+	switch s {
+	case Ydb_Table.IndexBuildState_STATE_PREPARING:
+		return IndexBuildStatePreparing
+	case Ydb_Table.IndexBuildState_STATE_TRANSFERING_DATA:
+		return IndexBuildStateTransferingData
+	case Ydb_Table.IndexBuildState_STATE_APPLYING:
+		return IndexBuildStateApplying
+	case Ydb_Table.IndexBuildState_STATE_DONE:
+		return IndexBuildStateDone
+	case Ydb_Table.IndexBuildState_STATE_CANCELLATION:
+		return IndexBuildStateCancellation
+	case Ydb_Table.IndexBuildState_STATE_CANCELLED:
+		return IndexBuildStateCancelled
+	case Ydb_Table.IndexBuildState_STATE_REJECTION:
+		return IndexBuildStateRejection
+	case Ydb_Table.IndexBuildState_STATE_REJECTED:
+		return IndexBuildStateRejected
+	default:
+		return IndexBuildStateUnknown
+	}
+}
+
+// IndexBuild is a progress of secondary index building.
+type IndexBuild struct {
+	Path     string
+	Index    string
+	State    IndexBuildState
+	Progress float32
+}
+
+// IndexBuild returns progress of index building if operation is an index build.
+func (o Operation) IndexBuild() (b IndexBuild, ok bool) {
+	var m Ydb_Table.IndexBuildMetadata
+	if o.metadata == nil || o.metadata.UnmarshalTo(&m) != nil {
+		return b, false
+	}
+	return IndexBuild{
+		Path:     m.GetDescription().GetPath(),
+		Index:    m.GetDescription().GetIndex().GetName(),
+		State:    indexBuildState(m.GetState()),
+		Progress: m.GetProgress(),
+	}, true
+}
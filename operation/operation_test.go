@@ -0,0 +1,84 @@
+package operation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestNew(t *testing.T) {
+	for _, test := range []struct {
+		op  *Ydb_Operations.Operation
+		err bool
+	}{
+		{
+			op: &Ydb_Operations.Operation{Id: "1"},
+		},
+		{
+			op: &Ydb_Operations.Operation{Id: "2", Ready: true, Status: Ydb.StatusIds_SUCCESS},
+		},
+		{
+			op:  &Ydb_Operations.Operation{Id: "3", Ready: true, Status: Ydb.StatusIds_CANCELLED},
+			err: true,
+		},
+	} {
+		op := New(test.op)
+		if op.ID != test.op.Id || op.Ready != test.op.Ready || (op.Err != nil) != test.err {
+			t.Errorf("unexpected operation: %+v", op)
+		}
+	}
+}
+
+func TestIndexBuild(t *testing.T) {
+	metadata, err := anypb.New(&Ydb_Table.IndexBuildMetadata{
+		Description: &Ydb_Table.IndexBuildDescription{
+			Path:  "/local/table",
+			Index: &Ydb_Table.TableIndex{Name: "idx"},
+		},
+		State:    Ydb_Table.IndexBuildState_STATE_TRANSFERING_DATA,
+		Progress: 42,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, ok := New(&Ydb_Operations.Operation{Id: "1", Metadata: metadata}).IndexBuild()
+	if !ok {
+		t.Fatal("index build metadata is not found")
+	}
+	if b.Path != "/local/table" || b.Index != "idx" || b.State != IndexBuildStateTransferingData || b.Progress != 42 {
+		t.Errorf("unexpected index build: %+v", b)
+	}
+	if _, ok = New(&Ydb_Operations.Operation{Id: "1"}).IndexBuild(); ok {
+		t.Error("unexpected index build metadata")
+	}
+}
+
+func TestContextParams(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := ContextTimeout(ctx); ok {
+		t.Fatalf("unexpected timeout")
+	}
+	if _, ok := ContextCancelAfter(ctx); ok {
+		t.Fatalf("unexpected cancel after")
+	}
+	ctx = WithTimeout(ctx, time.Second)
+	ctx = WithCancelAfter(ctx, time.Minute)
+	// Larger values do not override smaller ones.
+	ctx = WithTimeout(ctx, time.Hour)
+	ctx = WithCancelAfter(ctx, time.Hour)
+	if d, ok := ContextTimeout(ctx); !ok || d != time.Second {
+		t.Fatalf("unexpected timeout: %v", d)
+	}
+	if d, ok := ContextCancelAfter(ctx); !ok || d != time.Minute {
+		t.Fatalf("unexpected cancel after: %v", d)
+	}
+	ctx = WithTimeout(ctx, time.Millisecond)
+	if d, _ := ContextTimeout(ctx); d != time.Millisecond {
+		t.Fatalf("unexpected timeout: %v", d)
+	}
+}
@@ -23,6 +23,24 @@ func (t *lazyTable) Do(ctx context.Context, op table.Operation, opts ...table.Op
 	return t.client.Do(ctx, op, opts...)
 }
 
+func (t *lazyTable) Stats() table.Stats {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.client == nil {
+		return table.Stats{}
+	}
+	return t.client.Stats()
+}
+
+func (t *lazyTable) RecycleEndpoint(ctx context.Context, address string) int {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.client == nil {
+		return 0
+	}
+	return t.client.RecycleEndpoint(ctx, address)
+}
+
 func (t *lazyTable) Close(ctx context.Context) error {
 	t.m.Lock()
 	defer t.m.Unlock()
@@ -43,6 +61,13 @@ func (t *lazyTable) init(ctx context.Context) {
 	t.m.Unlock()
 }
 
+// warmUp starts session pool if warm-up of sessions is configured.
+func (t *lazyTable) warmUp(ctx context.Context) {
+	if config.New(t.options...).MinIdleSize() > 0 {
+		t.init(ctx)
+	}
+}
+
 func WithTableConfigOption(option config.Option) Option {
 	return func(ctx context.Context, c *db) error {
 		c.table.options = append(c.table.options, option)
@@ -64,6 +89,44 @@ func WithSessionPoolKeepAliveMinSize(keepAliveMinSize int) Option {
 	}
 }
 
+// WithSessionPoolMinIdleSize makes session pool create minIdleSize sessions
+// in background at start and keep at least minIdleSize idle sessions.
+// Session pool is started by New if minIdleSize is positive.
+func WithSessionPoolMinIdleSize(minIdleSize int) Option {
+	return func(ctx context.Context, c *db) error {
+		c.table.options = append(c.table.options, config.WithMinIdleSize(minIdleSize))
+		return nil
+	}
+}
+
+// WithSessionPoolWaitQueueLimit limits number of callers waiting for a session
+// when session pool is exhausted. Callers exceeding the limit fail fast with
+// table.ErrSessionPoolOverloaded.
+func WithSessionPoolWaitQueueLimit(waitQueueLimit int) Option {
+	return func(ctx context.Context, c *db) error {
+		c.table.options = append(c.table.options, config.WithWaitQueueLimit(waitQueueLimit))
+		return nil
+	}
+}
+
+// WithSessionPoolMaxSessionAge limits lifetime of sessions, older sessions
+// are closed on returning into the pool.
+func WithSessionPoolMaxSessionAge(maxSessionAge time.Duration) Option {
+	return func(ctx context.Context, c *db) error {
+		c.table.options = append(c.table.options, config.WithMaxSessionAge(maxSessionAge))
+		return nil
+	}
+}
+
+// WithSessionPoolMaxSessionUses limits number of session uses, exhausted
+// sessions are closed on returning into the pool.
+func WithSessionPoolMaxSessionUses(maxSessionUses int) Option {
+	return func(ctx context.Context, c *db) error {
+		c.table.options = append(c.table.options, config.WithMaxSessionUses(maxSessionUses))
+		return nil
+	}
+}
+
 func WithSessionPoolIdleThreshold(idleThreshold time.Duration) Option {
 	return func(ctx context.Context, c *db) error {
 		c.table.options = append(c.table.options, config.WithIdleThreshold(idleThreshold))
@@ -2,8 +2,14 @@ package table
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrSessionPoolOverloaded is returned when session pool is exhausted and
+// its wait queue is full (see config.WithWaitQueueLimit).
+var ErrSessionPoolOverloaded = errors.New("ydb: table: session pool overloaded")
+
 // Operation is the interface that holds an operation for retry.
 type Operation func(context.Context, Session) (err error)
 
@@ -29,6 +35,34 @@ func ContextIdempotentOperation(ctx context.Context) bool {
 	return ctx.Value(ctxIdempotentOperationKey{}) != nil
 }
 
+// Priority is a priority of waiting for a session when session pool is
+// exhausted. Waiting callers with higher priority get released sessions first,
+// callers with equal priorities are served in order of arrival.
+type Priority int
+
+const (
+	PriorityBatch       Priority = -1
+	PriorityNormal      Priority = 0
+	PriorityInteractive Priority = 1
+)
+
+type ctxPriorityKey struct{}
+
+// WithPriority returns a copy of parent context with priority of waiting for
+// a session.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, ctxPriorityKey{}, p)
+}
+
+// ContextPriority returns priority of waiting for a session from context.
+// It returns PriorityNormal if priority is not set.
+func ContextPriority(ctx context.Context) Priority {
+	if p, ok := ctx.Value(ctxPriorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
 type Client interface {
 	// Close closes table client
 	Close(ctx context.Context) error
@@ -39,4 +73,50 @@ type Client interface {
 	// - retry operation returned nil as error
 	// Warning: if deadline without deadline or cancellation func Retry will be worked infinite
 	Do(ctx context.Context, op Operation, opts ...Option) (err error)
+
+	// Stats returns a snapshot of session pool statistics.
+	Stats() Stats
+
+	// RecycleEndpoint closes idle sessions bound to the endpoint with given
+	// address and marks sessions in use to be closed on returning into the
+	// pool. It returns number of affected sessions.
+	RecycleEndpoint(ctx context.Context, address string) int
+}
+
+// Stats is a snapshot of session pool statistics.
+type Stats struct {
+	// Idle is a number of sessions stored in the pool.
+	Idle int
+	// InUse is a number of sessions taken from the pool (including sessions
+	// being kept alive at the moment).
+	InUse int
+	// Waiting is a number of callers waiting for a session.
+	Waiting int
+	// CreateInProgress is a number of sessions being created.
+	CreateInProgress int
+
+	MinSize int
+	MaxSize int
+
+	// Created and Closed are total numbers of sessions created and closed
+	// by the pool.
+	Created uint64
+	Closed  uint64
+
+	// KeepAliveFailures is a total number of failed keep-alive requests of
+	// idle sessions.
+	KeepAliveFailures uint64
+
+	// Endpoints is a distribution of sessions by endpoint addresses.
+	Endpoints map[string]EndpointStats
+
+	// AvgWaitTime is an average time callers spent in the wait queue.
+	// Callers which got a session without waiting are not counted.
+	AvgWaitTime time.Duration
+}
+
+// EndpointStats is a number of sessions bound to an endpoint.
+type EndpointStats struct {
+	Idle  int
+	InUse int
 }
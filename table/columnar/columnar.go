@@ -0,0 +1,387 @@
+// Package columnar converts streaming results into Arrow-like typed columns.
+//
+// Columns are not Apache Arrow arrays: for example, Bool values are []bool
+// instead of a bitmap and Decimal values are big-endian. Each column has the
+// name of the corresponding Arrow type, so it can be converted into Arrow
+// array by the caller.
+//
+// Each result set received from server (e.g. a part of StreamExecuteScanQuery
+// or StreamReadTable result) becomes a single Batch:
+//
+//     res, err := s.StreamExecuteScanQuery(ctx, query, params)
+//     if err != nil {
+//         // handle error
+//     }
+//     defer res.Close()
+//     for {
+//         batch, err := columnar.ReadBatch(ctx, res)
+//         if err == io.EOF {
+//             break
+//         }
+//         if err != nil {
+//             // handle error
+//         }
+//         ids := batch.Columns[0].Values.([]uint64)
+//         ...
+//     }
+//
+// YDB types are mapped to Arrow types and Go values of columns as follows:
+//
+//   Bool                          bool                    []bool
+//   Int8, Int16, Int32, Int64     int8 ... int64          []int8 ... []int64
+//   Uint8, Uint16, Uint32, Uint64 uint8 ... uint64        []uint8 ... []uint64
+//   Float, Double                 float32, float64        []float32, []float64
+//   Date                          date32                  []int32 of days since epoch
+//   Datetime                      timestamp[s, tz=UTC]    []int64 of seconds since epoch
+//   Timestamp                     timestamp[us, tz=UTC]   []int64 of microseconds since epoch
+//   Interval                      duration[us]            []int64 of microseconds
+//   String, Yson                  binary                  Offsets and Data
+//   Utf8, Json, JsonDocument,
+//   DyNumber, TzDate, TzDatetime,
+//   TzTimestamp                   utf8                    Offsets and Data
+//   Uuid                          fixed_size_binary[16]   [][16]byte
+//   Decimal(p,s)                  decimal128(p, s)        [][16]byte of big-endian integers
+//   Optional<T>                   nullable T              Valid bitmap
+//
+// Other types are not supported.
+package columnar
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/resultset"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// Field describes a column of the batch.
+type Field struct {
+	Name string
+	Type types.Type
+
+	// ArrowType is a name of Arrow data type of the column,
+	// e.g. "int64" or "decimal128(22, 9)".
+	ArrowType string
+
+	// Nullable is true for columns of Optional type.
+	Nullable bool
+}
+
+// Column contains values of a single column of the batch.
+type Column struct {
+	Field Field
+
+	// Len is a number of values in the column.
+	Len int
+
+	// NullN is a number of NULL values in the column.
+	NullN int
+
+	// Valid is a validity bitmap: i-th value is not NULL if bit i%8 of byte
+	// Valid[i/8] is set. Valid is nil if there are no NULL values.
+	Valid []byte
+
+	// Values contains values of fixed width types as typed slice, e.g. []int64.
+	// It is nil for binary and utf8 columns.
+	// Values of NULL items are zero.
+	Values interface{}
+
+	// Offsets and Data contain values of binary and utf8 columns:
+	// i-th value is Data[Offsets[i]:Offsets[i+1]].
+	Offsets []int32
+	Data    []byte
+}
+
+// IsNull reports whether i-th value of the column is NULL.
+func (c *Column) IsNull(i int) bool {
+	return c.Valid != nil && c.Valid[i/8]&(1<<(i%8)) == 0
+}
+
+// Bytes returns i-th value of binary or utf8 column.
+func (c *Column) Bytes(i int) []byte {
+	return c.Data[c.Offsets[i]:c.Offsets[i+1]]
+}
+
+// Batch is a set of equal length columns.
+type Batch struct {
+	Rows    int
+	Columns []Column
+}
+
+// Schema returns fields of the batch columns.
+func (b *Batch) Schema() []Field {
+	fields := make([]Field, len(b.Columns))
+	for i := range b.Columns {
+		fields[i] = b.Columns[i].Field
+	}
+	return fields
+}
+
+// rawResult is implemented by results of the table client and gives access to
+// result sets without scanning.
+type rawResult interface {
+	RawResultSet() *Ydb.ResultSet
+}
+
+// ReadBatch advances res to the next result set and returns its rows as Batch.
+// It returns io.EOF if there are no more result sets.
+func ReadBatch(ctx context.Context, res resultset.Result) (*Batch, error) {
+	if !res.NextResultSet(ctx) {
+		if err := res.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	var fields []Field
+	res.CurrentResultSet().Columns(func(c options.Column) {
+		fields = append(fields, Field{
+			Name: c.Name,
+			Type: c.Type,
+		})
+	})
+	for i := range fields {
+		if err := fields[i].resolve(); err != nil {
+			return nil, err
+		}
+	}
+	var rows []*Ydb.Value
+	if raw, ok := res.(rawResult); ok {
+		rows = raw.RawResultSet().GetRows()
+	} else {
+		var err error
+		if rows, err = scanRows(res, len(fields)); err != nil {
+			return nil, err
+		}
+	}
+	b := &Batch{
+		Rows:    len(rows),
+		Columns: make([]Column, len(fields)),
+	}
+	for i, f := range fields {
+		b.Columns[i] = newColumn(f, rows, i)
+	}
+	return b, nil
+}
+
+// scanRows scans rows of the current result set of res for implementations
+// of resultset.Result which do not provide raw result sets.
+func scanRows(res resultset.Result, n int) (rows []*Ydb.Value, err error) {
+	var (
+		values = make([]types.Value, n)
+		ptrs   = make([]interface{}, n)
+	)
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for res.NextRow() {
+		if err = res.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := &Ydb.Value{
+			Items: make([]*Ydb.Value, n),
+		}
+		for i, v := range values {
+			row.Items[i] = v.ToYDB().GetValue()
+		}
+		rows = append(rows, row)
+	}
+	return rows, res.Err()
+}
+
+func (f *Field) resolve() error {
+	t := f.Type
+	if optional, ok := t.(value.OptionalType); ok {
+		f.Nullable = true
+		t = optional.T
+	}
+	switch t := t.(type) {
+	case value.DecimalType:
+		f.ArrowType = fmt.Sprintf("decimal128(%d, %d)", t.Precision, t.Scale)
+		return nil
+	case value.PrimitiveType:
+		if s, ok := arrowTypes[t]; ok {
+			f.ArrowType = s
+			return nil
+		}
+	}
+	return fmt.Errorf("ydb: columnar: unsupported type %s of column %q", f.Type, f.Name)
+}
+
+var arrowTypes = map[value.PrimitiveType]string{
+	value.TypeBool:         "bool",
+	value.TypeInt8:         "int8",
+	value.TypeUint8:        "uint8",
+	value.TypeInt16:        "int16",
+	value.TypeUint16:       "uint16",
+	value.TypeInt32:        "int32",
+	value.TypeUint32:       "uint32",
+	value.TypeInt64:        "int64",
+	value.TypeUint64:       "uint64",
+	value.TypeFloat:        "float32",
+	value.TypeDouble:       "float64",
+	value.TypeDate:         "date32",
+	value.TypeDatetime:     "timestamp[s, tz=UTC]",
+	value.TypeTimestamp:    "timestamp[us, tz=UTC]",
+	value.TypeInterval:     "duration[us]",
+	value.TypeTzDate:       "utf8",
+	value.TypeTzDatetime:   "utf8",
+	value.TypeTzTimestamp:  "utf8",
+	value.TypeString:       "binary",
+	value.TypeUTF8:         "utf8",
+	value.TypeYSON:         "binary",
+	value.TypeJSON:         "utf8",
+	value.TypeUUID:         "fixed_size_binary[16]",
+	value.TypeJSONDocument: "utf8",
+	value.TypeDyNumber:     "utf8",
+}
+
+func newColumn(f Field, rows []*Ydb.Value, j int) Column {
+	c := Column{
+		Field: f,
+		Len:   len(rows),
+	}
+	items := make([]*Ydb.Value, len(rows))
+	for i, row := range rows {
+		v := row.GetItems()[j]
+		if _, null := v.GetValue().(*Ydb.Value_NullFlagValue); null {
+			if c.Valid == nil {
+				c.Valid = make([]byte, (len(rows)+7)/8)
+				for k := 0; k < i; k++ {
+					c.Valid[k/8] |= 1 << (k % 8)
+				}
+			}
+			c.NullN++
+			continue
+		}
+		if c.Valid != nil {
+			c.Valid[i/8] |= 1 << (i % 8)
+		}
+		items[i] = v
+	}
+	c.Values, c.Offsets, c.Data = fill(f.Type, items)
+	return c
+}
+
+func fill(t types.Type, items []*Ydb.Value) (values interface{}, offsets []int32, data []byte) {
+	// Items of NULL values are nil and getters of nil return zero values.
+	if optional, ok := t.(value.OptionalType); ok {
+		t = optional.T
+	}
+	n := len(items)
+	if _, ok := t.(value.DecimalType); ok {
+		return fill128(items), nil, nil
+	}
+	switch t.(value.PrimitiveType) {
+	case value.TypeBool:
+		vs := make([]bool, n)
+		for i, v := range items {
+			vs[i] = v.GetBoolValue()
+		}
+		return vs, nil, nil
+	case value.TypeInt8:
+		vs := make([]int8, n)
+		for i, v := range items {
+			vs[i] = int8(v.GetInt32Value())
+		}
+		return vs, nil, nil
+	case value.TypeUint8:
+		vs := make([]uint8, n)
+		for i, v := range items {
+			vs[i] = uint8(v.GetUint32Value())
+		}
+		return vs, nil, nil
+	case value.TypeInt16:
+		vs := make([]int16, n)
+		for i, v := range items {
+			vs[i] = int16(v.GetInt32Value())
+		}
+		return vs, nil, nil
+	case value.TypeUint16:
+		vs := make([]uint16, n)
+		for i, v := range items {
+			vs[i] = uint16(v.GetUint32Value())
+		}
+		return vs, nil, nil
+	case value.TypeInt32:
+		vs := make([]int32, n)
+		for i, v := range items {
+			vs[i] = v.GetInt32Value()
+		}
+		return vs, nil, nil
+	case value.TypeUint32:
+		vs := make([]uint32, n)
+		for i, v := range items {
+			vs[i] = v.GetUint32Value()
+		}
+		return vs, nil, nil
+	case value.TypeInt64, value.TypeInterval:
+		vs := make([]int64, n)
+		for i, v := range items {
+			vs[i] = v.GetInt64Value()
+		}
+		return vs, nil, nil
+	case value.TypeUint64:
+		vs := make([]uint64, n)
+		for i, v := range items {
+			vs[i] = v.GetUint64Value()
+		}
+		return vs, nil, nil
+	case value.TypeFloat:
+		vs := make([]float32, n)
+		for i, v := range items {
+			vs[i] = v.GetFloatValue()
+		}
+		return vs, nil, nil
+	case value.TypeDouble:
+		vs := make([]float64, n)
+		for i, v := range items {
+			vs[i] = v.GetDoubleValue()
+		}
+		return vs, nil, nil
+	case value.TypeDate:
+		vs := make([]int32, n)
+		for i, v := range items {
+			vs[i] = int32(v.GetUint32Value())
+		}
+		return vs, nil, nil
+	case value.TypeDatetime:
+		vs := make([]int64, n)
+		for i, v := range items {
+			vs[i] = int64(v.GetUint32Value())
+		}
+		return vs, nil, nil
+	case value.TypeTimestamp:
+		vs := make([]int64, n)
+		for i, v := range items {
+			vs[i] = int64(v.GetUint64Value())
+		}
+		return vs, nil, nil
+	case value.TypeUUID:
+		return fill128(items), nil, nil
+	default:
+		offsets = make([]int32, n+1)
+		for i, v := range items {
+			switch x := v.GetValue().(type) {
+			case *Ydb.Value_BytesValue:
+				data = append(data, x.BytesValue...)
+			case *Ydb.Value_TextValue:
+				data = append(data, x.TextValue...)
+			}
+			offsets[i+1] = int32(len(data))
+		}
+		return nil, offsets, data
+	}
+}
+
+func fill128(items []*Ydb.Value) [][16]byte {
+	vs := make([][16]byte, len(items))
+	for i, v := range items {
+		vs[i] = value.BigEndianUint128(v.GetHigh_128(), v.GetLow_128())
+	}
+	return vs
+}
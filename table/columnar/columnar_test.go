@@ -0,0 +1,133 @@
+package columnar
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func resultSet(columns []*Ydb.Column, rows ...[]types.Value) *Ydb.ResultSet {
+	set := &Ydb.ResultSet{
+		Columns: columns,
+	}
+	for _, row := range rows {
+		r := &Ydb.Value{}
+		for _, v := range row {
+			r.Items = append(r.Items, v.ToYDB().GetValue())
+		}
+		set.Rows = append(set.Rows, r)
+	}
+	return set
+}
+
+func TestReadBatch(t *testing.T) {
+	columns := []*Ydb.Column{
+		{Name: "id", Type: value.TypeToYDB(types.TypeUint64)},
+		{Name: "title", Type: value.TypeToYDB(types.Optional(types.TypeUTF8))},
+		{Name: "price", Type: value.TypeToYDB(types.DecimalType(22, 9))},
+		{Name: "at", Type: value.TypeToYDB(types.Optional(types.TypeTimestamp))},
+	}
+	price := func(x uint64) types.Value {
+		return value.DecimalValue(value.DecimalType{Precision: 22, Scale: 9}, value.BigEndianUint128(0, x))
+	}
+	res := &scanner.Result{
+		Sets: []*Ydb.ResultSet{
+			resultSet(columns,
+				[]types.Value{
+					types.Uint64Value(1),
+					types.OptionalValue(types.UTF8Value("ab")),
+					price(1),
+					types.NullValue(types.TypeTimestamp),
+				},
+				[]types.Value{
+					types.Uint64Value(2),
+					types.NullValue(types.TypeUTF8),
+					price(2),
+					types.NullValue(types.TypeTimestamp),
+				},
+				[]types.Value{
+					types.Uint64Value(3),
+					types.OptionalValue(types.UTF8Value("c")),
+					price(3),
+					types.OptionalValue(types.TimestampValue(42)),
+				},
+			),
+			resultSet(columns),
+		},
+	}
+	ctx := context.Background()
+	b, err := ReadBatch(ctx, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Rows != 3 {
+		t.Fatalf("unexpected rows: %d", b.Rows)
+	}
+	var arrowTypes []string
+	for _, f := range b.Schema() {
+		arrowTypes = append(arrowTypes, f.ArrowType)
+	}
+	if exp := []string{"uint64", "utf8", "decimal128(22, 9)", "timestamp[us, tz=UTC]"}; !reflect.DeepEqual(arrowTypes, exp) {
+		t.Errorf("unexpected arrow types: %v, exp: %v", arrowTypes, exp)
+	}
+
+	id := b.Columns[0]
+	if id.Field.Nullable || id.Valid != nil || id.NullN != 0 {
+		t.Errorf("unexpected nullability of %+v", id)
+	}
+	if exp := []uint64{1, 2, 3}; !reflect.DeepEqual(id.Values, exp) {
+		t.Errorf("unexpected values: %v, exp: %v", id.Values, exp)
+	}
+
+	title := b.Columns[1]
+	if !title.Field.Nullable || title.NullN != 1 || !reflect.DeepEqual(title.Valid, []byte{0x05}) {
+		t.Errorf("unexpected nullability of %+v", title)
+	}
+	if exp := []int32{0, 2, 2, 3}; !reflect.DeepEqual(title.Offsets, exp) {
+		t.Errorf("unexpected offsets: %v, exp: %v", title.Offsets, exp)
+	}
+	if act := string(title.Bytes(2)); act != "c" || !title.IsNull(1) || title.IsNull(0) {
+		t.Errorf("unexpected value: %q", act)
+	}
+
+	prices := b.Columns[2].Values.([][16]byte)
+	if prices[2] != value.BigEndianUint128(0, 3) {
+		t.Errorf("unexpected decimal: %v", prices[2])
+	}
+
+	at := b.Columns[3]
+	if exp := []int64{0, 0, 42}; !reflect.DeepEqual(at.Values, exp) || at.NullN != 2 {
+		t.Errorf("unexpected values: %v, exp: %v", at.Values, exp)
+	}
+
+	b, err = ReadBatch(ctx, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Rows != 0 || len(b.Columns) != 4 || len(b.Columns[1].Offsets) != 1 {
+		t.Errorf("unexpected empty batch: %+v", b)
+	}
+	if _, err = ReadBatch(ctx, res); err != io.EOF {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReadBatchUnsupported(t *testing.T) {
+	res := &scanner.Result{
+		Sets: []*Ydb.ResultSet{
+			resultSet([]*Ydb.Column{
+				{Name: "tags", Type: value.TypeToYDB(types.List(types.TypeUTF8))},
+			}),
+		},
+	}
+	if _, err := ReadBatch(context.Background(), res); err == nil {
+		t.Fatal("expected error")
+	}
+}
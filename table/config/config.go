@@ -39,6 +39,41 @@ type Config interface {
 	// If IdleKeepAliveThreshold is equal to zero, it will be set to DefaultIdleKeepAliveThreshold
 	IdleKeepAliveThreshold() int
 
+	// MinIdleSize is a number of idle sessions which are created in
+	// background at start of the pool and are kept after sessions are taken
+	// from the pool, so the first requests do not wait for session creation.
+	// Sessions are created through the balancer and thus are spread across
+	// endpoints.
+	// MinIdleSize is limited by SizeLimit. Excess idle sessions are not
+	// removed by keeper while number of sessions is less than MinIdleSize.
+	// If MinIdleSize is zero (the default) then sessions are created on
+	// demand only.
+	MinIdleSize() int
+
+	// WaitQueueLimit is an upper bound of callers waiting for a session when
+	// the pool is exhausted. Callers exceeding the limit fail fast with
+	// table.ErrSessionPoolOverloaded.
+	// If WaitQueueLimit is zero (the default) then number of waiting callers
+	// is not limited.
+	WaitQueueLimit() int
+
+	// MaxSessionAge is an upper bound of session lifetime. Older sessions
+	// are closed on returning into the pool instead of becoming idle.
+	// If MaxSessionAge is zero (the default) then lifetime of sessions is not
+	// limited.
+	//
+	// Limit of each session is randomly reduced up to 10% to avoid
+	// simultaneous recycling of sessions created at once. The same applies to
+	// MaxSessionUses.
+	MaxSessionAge() time.Duration
+
+	// MaxSessionUses is an upper bound of times session is got from the pool.
+	// Session used MaxSessionUses times is closed on returning into the pool
+	// instead of becoming idle.
+	// If MaxSessionUses is zero (the default) then number of session uses is
+	// not limited.
+	MaxSessionUses() int
+
 	// IdleLimit is an upper bound of pooled sessions without any activity
 	// within.
 	// IdleLimit int
@@ -103,6 +138,42 @@ func WithIdleKeepAliveThreshold(idleKeepAliveThreshold int) Option {
 	}
 }
 
+func WithMinIdleSize(minIdleSize int) Option {
+	return func(c *config) {
+		if minIdleSize < 0 {
+			minIdleSize = 0
+		}
+		c.minIdleSize = minIdleSize
+	}
+}
+
+func WithWaitQueueLimit(waitQueueLimit int) Option {
+	return func(c *config) {
+		if waitQueueLimit < 0 {
+			waitQueueLimit = 0
+		}
+		c.waitQueueLimit = waitQueueLimit
+	}
+}
+
+func WithMaxSessionAge(maxSessionAge time.Duration) Option {
+	return func(c *config) {
+		if maxSessionAge < 0 {
+			maxSessionAge = 0
+		}
+		c.maxSessionAge = maxSessionAge
+	}
+}
+
+func WithMaxSessionUses(maxSessionUses int) Option {
+	return func(c *config) {
+		if maxSessionUses < 0 {
+			maxSessionUses = 0
+		}
+		c.maxSessionUses = maxSessionUses
+	}
+}
+
 func WithIdleThreshold(idleThreshold time.Duration) Option {
 	return func(c *config) {
 		if idleThreshold < 0 {
@@ -146,6 +217,10 @@ type config struct {
 	sizeLimit              int
 	keepAliveMinSize       int
 	idleKeepAliveThreshold int
+	minIdleSize            int
+	waitQueueLimit         int
+	maxSessionAge          time.Duration
+	maxSessionUses         int
 	idleThreshold          time.Duration
 	keepAliveTimeout       time.Duration
 	createSessionTimeout   time.Duration
@@ -169,6 +244,25 @@ func (c *config) IdleKeepAliveThreshold() int {
 	return c.idleKeepAliveThreshold
 }
 
+func (c *config) MinIdleSize() int {
+	if c.minIdleSize > c.sizeLimit {
+		return c.sizeLimit
+	}
+	return c.minIdleSize
+}
+
+func (c *config) WaitQueueLimit() int {
+	return c.waitQueueLimit
+}
+
+func (c *config) MaxSessionAge() time.Duration {
+	return c.maxSessionAge
+}
+
+func (c *config) MaxSessionUses() int {
+	return c.maxSessionUses
+}
+
 func (c *config) IdleThreshold() time.Duration {
 	return c.idleThreshold
 }
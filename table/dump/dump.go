@@ -0,0 +1,360 @@
+// Package dump exports tables into CSV or TSV and imports them back.
+//
+// Export streams rows with Session.StreamReadTable and writes them with
+// a header row of column names:
+//
+//     n, err := dump.Export(ctx, s, path, os.Stdout,
+//         dump.WithReadOptions(
+//             options.ReadColumn("id"),
+//             options.ReadColumn("title"),
+//             options.ReadOrdered(),
+//         ),
+//     )
+//
+// Import parses rows according to the columns of DescribeTable and loads
+// them in batches with Session.BulkUpsert:
+//
+//     stats, err := dump.Import(ctx, s, path, os.Stdin,
+//         dump.WithBadRow(func(row dump.BadRow) error {
+//             log.Printf("skip row %d: %v", row.Row, row.Err)
+//             return nil
+//         }),
+//     )
+//
+// Primitive and decimal values are formatted as types.FormatText does,
+// e.g. "2020-01-01" for Date or "PT1.5S" for Interval.
+// Values of String type are encoded with standard base64 encoding, as
+// types.MarshalJSON does, so binary data is not corrupted.
+// Values of container types are formatted as types.MarshalJSON does.
+// NULL is written as DefaultNull unless WithNull is given, so NULL differs
+// from an empty string.
+package dump
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+const (
+	// DefaultBatchSize is a default number of rows in a single BulkUpsert call.
+	DefaultBatchSize = 1000
+
+	// DefaultNull is a default text representation of NULL values.
+	DefaultNull = `\N`
+)
+
+// Stats describes progress of export or import.
+type Stats struct {
+	// Rows is a number of rows written or upserted.
+	Rows uint64
+
+	// BadRows is a number of skipped rows during import.
+	BadRows uint64
+}
+
+// BadRow describes a row which cannot be imported.
+type BadRow struct {
+	// Row is a number of the row in the input starting from 1.
+	// Header row is not counted.
+	Row    uint64
+	Record []string
+	Err    error
+}
+
+type config struct {
+	comma     rune
+	null      string
+	read      []options.ReadTableOption
+	batchSize int
+	progress  func(Stats)
+	badRow    func(BadRow) error
+}
+
+type Option func(c *config)
+
+// WithComma sets field delimiter. Default is comma.
+func WithComma(comma rune) Option {
+	return func(c *config) {
+		c.comma = comma
+	}
+}
+
+// WithTSV sets tab as field delimiter.
+func WithTSV() Option {
+	return WithComma('\t')
+}
+
+// WithNull sets text representation of NULL values, e.g. an empty string.
+// Default is DefaultNull. Note that empty strings are indistinguishable from
+// NULL if null is empty.
+func WithNull(null string) Option {
+	return func(c *config) {
+		c.null = null
+	}
+}
+
+// WithReadOptions sets options of StreamReadTable for Export,
+// such as columns, key range and ordering.
+func WithReadOptions(opts ...options.ReadTableOption) Option {
+	return func(c *config) {
+		c.read = append(c.read, opts...)
+	}
+}
+
+// WithBatchSize sets number of rows in a single BulkUpsert call of Import.
+func WithBatchSize(n int) Option {
+	return func(c *config) {
+		c.batchSize = n
+	}
+}
+
+// WithProgress sets callback which is called after each result set
+// of Export and each batch of Import.
+func WithProgress(progress func(Stats)) Option {
+	return func(c *config) {
+		c.progress = progress
+	}
+}
+
+// WithBadRow sets callback for rows which cannot be imported.
+// Bad row is skipped if callback returns nil, otherwise Import stops with
+// returned error. Without callback Import stops on the first bad row.
+func WithBadRow(badRow func(BadRow) error) Option {
+	return func(c *config) {
+		c.badRow = badRow
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		comma:     ',',
+		null:      DefaultNull,
+		batchSize: DefaultBatchSize,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.batchSize <= 0 {
+		c.batchSize = DefaultBatchSize
+	}
+	return c
+}
+
+// Export writes rows of the table with given path into w.
+// It returns number of written rows.
+func Export(ctx context.Context, s table.Session, path string, w io.Writer, opts ...Option) (rows uint64, err error) {
+	c := newConfig(opts)
+
+	var desc options.ReadTableDesc
+	for _, opt := range c.read {
+		opt(&desc)
+	}
+	columns := desc.Columns
+	if len(columns) == 0 {
+		d, err := s.DescribeTable(ctx, path)
+		if err != nil {
+			return 0, err
+		}
+		for _, column := range d.Columns {
+			columns = append(columns, column.Name)
+		}
+	}
+
+	res, err := s.StreamReadTable(ctx, path, c.read...)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = res.Close()
+	}()
+
+	cw := csv.NewWriter(w)
+	cw.Comma = c.comma
+	if err = cw.Write(columns); err != nil {
+		return 0, err
+	}
+	var (
+		values = make([]types.Value, len(columns))
+		ptrs   = make([]interface{}, len(columns))
+		record = make([]string, len(columns))
+	)
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for res.NextResultSet(ctx, columns...) {
+		for res.NextRow() {
+			if err = res.Scan(ptrs...); err != nil {
+				return rows, err
+			}
+			for i, v := range values {
+				if record[i], err = formatField(v, c.null); err != nil {
+					return rows, fmt.Errorf("ydb: dump: column %q: %w", columns[i], err)
+				}
+			}
+			if err = cw.Write(record); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		cw.Flush()
+		if err = cw.Error(); err != nil {
+			return rows, err
+		}
+		if c.progress != nil {
+			c.progress(Stats{Rows: rows})
+		}
+	}
+	if err = res.Err(); err != nil {
+		return rows, err
+	}
+	cw.Flush()
+	return rows, cw.Error()
+}
+
+func formatField(v types.Value, null string) (string, error) {
+	for {
+		item, ok, err := types.OptionalItem(v)
+		if err != nil {
+			break
+		}
+		if !ok {
+			return null, nil
+		}
+		v = item
+	}
+	switch t := types.TypeOf(v).(type) {
+	case value.PrimitiveType, value.DecimalType:
+		if t == types.TypeString {
+			b, err := types.AsString(v)
+			return base64.StdEncoding.EncodeToString(b), err
+		}
+		return types.FormatText(v)
+	default:
+		b, err := types.MarshalJSON(v)
+		return string(b), err
+	}
+}
+
+// Import parses rows from r and upserts them into the table with given path.
+// The first row of r must contain column names of the table.
+// Columns which are absent in r are not changed by upsert.
+func Import(ctx context.Context, s table.Session, path string, r io.Reader, opts ...Option) (stats Stats, err error) {
+	c := newConfig(opts)
+
+	d, err := s.DescribeTable(ctx, path)
+	if err != nil {
+		return stats, err
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = c.comma
+	header, err := cr.Read()
+	if err != nil {
+		return stats, fmt.Errorf("ydb: dump: read header: %w", err)
+	}
+	columns := make([]options.Column, len(header))
+	for i, name := range header {
+		found := false
+		for _, column := range d.Columns {
+			if column.Name == name {
+				columns[i], found = column, true
+				break
+			}
+		}
+		if !found {
+			return stats, fmt.Errorf("ydb: dump: unknown column %q of table %q", name, path)
+		}
+	}
+
+	batch := make([]types.Value, 0, c.batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.BulkUpsert(ctx, path, types.ListValue(batch...)); err != nil {
+			return err
+		}
+		stats.Rows += uint64(len(batch))
+		batch = batch[:0]
+		if c.progress != nil {
+			c.progress(stats)
+		}
+		return nil
+	}
+	for n := uint64(1); ; n++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		var row types.Value
+		if err == nil {
+			row, err = parseRow(columns, record, c.null)
+		} else if _, ok := err.(*csv.ParseError); !ok {
+			return stats, err
+		}
+		if err != nil {
+			if c.badRow == nil {
+				return stats, fmt.Errorf("ydb: dump: row %d: %w", n, err)
+			}
+			if err = c.badRow(BadRow{Row: n, Record: record, Err: err}); err != nil {
+				return stats, err
+			}
+			stats.BadRows++
+			continue
+		}
+		batch = append(batch, row)
+		if len(batch) == c.batchSize {
+			if err = flush(); err != nil {
+				return stats, err
+			}
+		}
+	}
+	err = flush()
+	return stats, err
+}
+
+func parseRow(columns []options.Column, record []string, null string) (types.Value, error) {
+	fields := make([]types.StructValueOption, len(columns))
+	for i, column := range columns {
+		v, err := parseField(column.Type, record[i], null)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", column.Name, err)
+		}
+		fields[i] = types.StructFieldValue(column.Name, v)
+	}
+	return types.StructValue(fields...), nil
+}
+
+func parseField(t types.Type, s, null string) (types.Value, error) {
+	if optional, ok := t.(value.OptionalType); ok {
+		if s == null {
+			return types.NullValue(optional.T), nil
+		}
+		v, err := parseField(optional.T, s, null)
+		if err != nil {
+			return nil, err
+		}
+		return types.OptionalValue(v), nil
+	}
+	switch t.(type) {
+	case value.PrimitiveType, value.DecimalType:
+		if t == types.TypeString {
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, err
+			}
+			return types.StringValue(b), nil
+		}
+		return types.ParseText(t, s)
+	default:
+		return types.UnmarshalJSON([]byte(s), t)
+	}
+}
@@ -0,0 +1,217 @@
+package dump
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/resultset"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type session struct {
+	table.Session
+
+	columns []options.Column
+	set     *Ydb.ResultSet
+	batches []types.Value
+}
+
+func (s *session) StreamReadTable(context.Context, string, ...options.ReadTableOption) (resultset.Result, error) {
+	return &scanner.Result{Sets: []*Ydb.ResultSet{s.set}}, nil
+}
+
+func (s *session) DescribeTable(context.Context, string, ...options.DescribeTableOption) (options.Description, error) {
+	return options.Description{Columns: s.columns}, nil
+}
+
+func (s *session) BulkUpsert(_ context.Context, _ string, rows types.Value) error {
+	s.batches = append(s.batches, rows)
+	return nil
+}
+
+func TestFormatField(t *testing.T) {
+	for _, test := range []struct {
+		value types.Value
+		exp   string
+	}{
+		{value: types.Int32Value(-1), exp: "-1"},
+		{value: types.StringValue([]byte("a,b")), exp: "YSxi"},
+		{value: types.StringValue([]byte{0xff, '\n'}), exp: "/wo="},
+		{value: types.DateValue(1), exp: "1970-01-02"},
+		{value: types.OptionalValue(types.UTF8Value("a")), exp: "a"},
+		{value: types.OptionalValue(types.UTF8Value("")), exp: ""},
+		{value: types.NullValue(types.TypeUTF8), exp: `\N`},
+		{value: types.ListValue(types.Int32Value(1), types.Int32Value(2)), exp: "[1,2]"},
+	} {
+		act, err := formatField(test.value, `\N`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act != test.exp {
+			t.Errorf("unexpected field: %q, exp: %q", act, test.exp)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	s := &session{
+		columns: []options.Column{
+			{Name: "id", Type: types.TypeUint64},
+			{Name: "title", Type: types.Optional(types.TypeUTF8)},
+		},
+		set: &Ydb.ResultSet{
+			Columns: []*Ydb.Column{
+				{Name: "title", Type: value.TypeToYDB(types.Optional(types.TypeUTF8))},
+				{Name: "id", Type: value.TypeToYDB(types.TypeUint64)},
+			},
+			Rows: []*Ydb.Value{
+				{Items: []*Ydb.Value{
+					types.OptionalValue(types.UTF8Value("a,\"b\"")).ToYDB().Value,
+					types.Uint64Value(1).ToYDB().Value,
+				}},
+				{Items: []*Ydb.Value{
+					types.NullValue(types.TypeUTF8).ToYDB().Value,
+					types.Uint64Value(2).ToYDB().Value,
+				}},
+			},
+		},
+	}
+	var (
+		buf      bytes.Buffer
+		progress []Stats
+	)
+	rows, err := Export(context.Background(), s, "t", &buf,
+		WithProgress(func(stats Stats) {
+			progress = append(progress, stats)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows != 2 || len(progress) != 1 || progress[0].Rows != 2 {
+		t.Errorf("unexpected rows: %d, progress: %+v", rows, progress)
+	}
+	exp := "id,title\n" +
+		"1,\"a,\"\"b\"\"\"\n" +
+		"2,\\N\n"
+	if act := buf.String(); act != exp {
+		t.Errorf("unexpected output:\n%s\nexp:\n%s", act, exp)
+	}
+}
+
+func TestImport(t *testing.T) {
+	s := &session{
+		columns: []options.Column{
+			{Name: "id", Type: types.TypeUint64},
+			{Name: "title", Type: types.Optional(types.TypeUTF8)},
+			{Name: "tags", Type: types.Optional(types.List(types.TypeUTF8))},
+		},
+	}
+	input := "title\tid\ttags\n" +
+		"a\t1\t\"[\"\"x\"\"]\"\n" +
+		"\\N\t2\t\\N\n" +
+		"b\t-3\t\\N\n" +
+		"c\n" +
+		"d\t4\t[]\n"
+	var (
+		bad      []BadRow
+		progress []Stats
+	)
+	stats, err := Import(context.Background(), s, "t", strings.NewReader(input),
+		WithTSV(),
+		WithBatchSize(2),
+		WithBadRow(func(row BadRow) error {
+			bad = append(bad, row)
+			return nil
+		}),
+		WithProgress(func(stats Stats) {
+			progress = append(progress, stats)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (Stats{Rows: 3, BadRows: 2}); stats != exp {
+		t.Errorf("unexpected stats: %+v, exp: %+v", stats, exp)
+	}
+	if len(progress) != 2 || progress[1] != stats {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+	if len(bad) != 2 || bad[0].Row != 3 || bad[1].Row != 4 {
+		t.Errorf("unexpected bad rows: %+v", bad)
+	}
+	if len(s.batches) != 2 {
+		t.Fatalf("unexpected batches count: %d", len(s.batches))
+	}
+	row := func(id uint64, title types.Value, tags types.Value) types.Value {
+		return types.StructValue(
+			types.StructFieldValue("title", title),
+			types.StructFieldValue("id", types.Uint64Value(id)),
+			types.StructFieldValue("tags", tags),
+		)
+	}
+	exp := types.ListValue(
+		row(1, types.OptionalValue(types.UTF8Value("a")), types.OptionalValue(types.ListValue(types.UTF8Value("x")))),
+		row(2, types.NullValue(types.TypeUTF8), types.NullValue(types.List(types.TypeUTF8))),
+	)
+	if act, exp := types.FormatYQL(s.batches[0]), types.FormatYQL(exp); act != exp {
+		t.Errorf("unexpected batch:\n%s\nexp:\n%s", act, exp)
+	}
+}
+
+func TestImportStopsOnBadRow(t *testing.T) {
+	s := &session{
+		columns: []options.Column{
+			{Name: "id", Type: types.TypeUint64},
+		},
+	}
+	_, err := Import(context.Background(), s, "t", strings.NewReader("id\nx\n"))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	errStop := errors.New("stop")
+	_, err = Import(context.Background(), s, "t", strings.NewReader("id\nx\n"),
+		WithBadRow(func(BadRow) error {
+			return errStop
+		}),
+	)
+	if !errors.Is(err, errStop) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = Import(context.Background(), s, "t", strings.NewReader("name\n"))
+	if err == nil {
+		t.Fatal("expected error on unknown column")
+	}
+}
+
+func TestParseField(t *testing.T) {
+	for _, test := range []struct {
+		t   types.Type
+		s   string
+		exp types.Value
+	}{
+		{t: types.Optional(types.TypeUTF8), s: "", exp: types.OptionalValue(types.UTF8Value(""))},
+		{t: types.Optional(types.TypeUTF8), s: `\N`, exp: types.NullValue(types.TypeUTF8)},
+		{t: types.TypeString, s: "/wo=", exp: types.StringValue([]byte{0xff, '\n'})},
+	} {
+		act, err := parseField(test.t, test.s, DefaultNull)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act, exp := types.FormatYQL(act), types.FormatYQL(test.exp); act != exp {
+			t.Errorf("unexpected value of %q: %s, exp: %s", test.s, act, exp)
+		}
+	}
+	if _, err := parseField(types.TypeString, "!", DefaultNull); err == nil {
+		t.Error("expected error on invalid base64")
+	}
+}
@@ -0,0 +1,200 @@
+package options
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// ErrImpossibleAlter returned from Diff when desired description cannot be reached with AlterTable.
+var ErrImpossibleAlter = errors.New("ydb: table: impossible alter")
+
+// Diff compares actual (result of DescribeTable) and desired table descriptions
+// and returns AlterTable options which turns actual description into desired.
+//
+// Zero values of ReadReplicaSettings, StorageSettings, KeyBloomFilter, PartitioningSettings
+// and nil Attributes and ColumnFamilies of desired description are treated as unspecified
+// and not compared. If ColumnFamilies are unspecified then empty Family of desired columns
+// is unspecified too, e.g. DescribeTable returns "default" family which is not required in
+// desired description.
+//
+// Changes of primary key, column types, existing indexes and dropping of column families are not possible with
+// AlterTable. In this case Diff returns options for the rest of changes and the error which
+// wraps ErrImpossibleAlter and enumerates all impossible changes.
+func Diff(actual, desired Description) (opts []AlterTableOption, err error) {
+	var impossible []string
+
+	if !equalStrings(actual.PrimaryKey, desired.PrimaryKey) {
+		impossible = append(impossible, fmt.Sprintf(
+			"primary key changed from %v to %v", actual.PrimaryKey, desired.PrimaryKey,
+		))
+	}
+
+	actualColumns := make(map[string]Column, len(actual.Columns))
+	for _, c := range actual.Columns {
+		actualColumns[c.Name] = c
+	}
+	desiredColumns := make(map[string]Column, len(desired.Columns))
+	for _, c := range desired.Columns {
+		desiredColumns[c.Name] = c
+		a, ok := actualColumns[c.Name]
+		switch {
+		case !ok:
+			opts = append(opts, WithAddColumnMeta(c))
+		case !value.TypesEqual(a.Type, c.Type):
+			impossible = append(impossible, fmt.Sprintf(
+				"type of column %q changed from %s to %s", c.Name, typeString(a.Type), typeString(c.Type),
+			))
+		case a.Family != c.Family && (c.Family != "" || desired.ColumnFamilies != nil):
+			opts = append(opts, WithSetColumnFamily(c.Name, c.Family))
+		}
+	}
+	for _, c := range actual.Columns {
+		if _, ok := desiredColumns[c.Name]; !ok {
+			opts = append(opts, WithDropColumn(c.Name))
+		}
+	}
+
+	actualIndexes := make(map[string]IndexDescription, len(actual.Indexes))
+	for _, idx := range actual.Indexes {
+		actualIndexes[idx.Name] = idx
+	}
+	desiredIndexes := make(map[string]struct{}, len(desired.Indexes))
+	for _, idx := range desired.Indexes {
+		desiredIndexes[idx.Name] = struct{}{}
+		a, ok := actualIndexes[idx.Name]
+		switch {
+		case !ok:
+			opts = append(opts, withAddIndexDescription(idx))
+		case !equalStrings(a.IndexColumns, idx.IndexColumns) ||
+			!equalStrings(a.DataColumns, idx.DataColumns) ||
+			(idx.Type != nil && a.Type != idx.Type):
+			impossible = append(impossible, fmt.Sprintf(
+				"index %q changed, it must be added with another name", idx.Name,
+			))
+		}
+	}
+	for _, idx := range actual.Indexes {
+		if _, ok := desiredIndexes[idx.Name]; !ok {
+			opts = append(opts, WithDropIndex(idx.Name))
+		}
+	}
+
+	if desired.ColumnFamilies != nil {
+		actualFamilies := make(map[string]ColumnFamily, len(actual.ColumnFamilies))
+		for _, cf := range actual.ColumnFamilies {
+			actualFamilies[cf.Name] = cf
+		}
+		desiredFamilies := make(map[string]struct{}, len(desired.ColumnFamilies))
+		var addFamilies, alterFamilies []ColumnFamily
+		for _, cf := range desired.ColumnFamilies {
+			desiredFamilies[cf.Name] = struct{}{}
+			a, ok := actualFamilies[cf.Name]
+			switch {
+			case !ok:
+				addFamilies = append(addFamilies, cf)
+			case !proto.Equal(a.toYDB(), cf.toYDB()):
+				alterFamilies = append(alterFamilies, cf)
+			}
+		}
+		if len(addFamilies) > 0 {
+			opts = append(opts, WithAddColumnFamilies(addFamilies...))
+		}
+		if len(alterFamilies) > 0 {
+			opts = append(opts, WithAlterColumnFamilies(alterFamilies...))
+		}
+		for _, cf := range actual.ColumnFamilies {
+			if _, ok := desiredFamilies[cf.Name]; !ok {
+				impossible = append(impossible, fmt.Sprintf("column family %q dropped", cf.Name))
+			}
+		}
+	}
+
+	if desired.Attributes != nil {
+		keys := make([]string, 0, len(desired.Attributes)+len(actual.Attributes))
+		for k := range desired.Attributes {
+			keys = append(keys, k)
+		}
+		for k := range actual.Attributes {
+			if _, ok := desired.Attributes[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v, ok := desired.Attributes[k]
+			switch {
+			case !ok:
+				opts = append(opts, WithDropAttribute(k))
+			case actual.Attributes[k] != v:
+				opts = append(opts, WithAlterAttribute(k, v))
+			}
+		}
+	}
+
+	switch {
+	case desired.TimeToLiveSettings == nil && actual.TimeToLiveSettings != nil:
+		opts = append(opts, WithDropTimeToLive())
+	case desired.TimeToLiveSettings != nil &&
+		!proto.Equal(desired.TimeToLiveSettings.ToYDB(), actual.TimeToLiveSettings.ToYDB()):
+		opts = append(opts, WithSetTimeToLiveSettings(*desired.TimeToLiveSettings))
+	}
+
+	if desired.PartitioningSettings != (PartitioningSettings{}) &&
+		desired.PartitioningSettings != actual.PartitioningSettings {
+		opts = append(opts, WithAlterPartitionSettingsObject(desired.PartitioningSettings))
+	}
+	if desired.ReadReplicaSettings != (ReadReplicasSettings{}) &&
+		desired.ReadReplicaSettings != actual.ReadReplicaSettings {
+		opts = append(opts, WithAlterReadReplicasSettings(desired.ReadReplicaSettings))
+	}
+	if desired.StorageSettings != (StorageSettings{}) &&
+		desired.StorageSettings != actual.StorageSettings {
+		opts = append(opts, WithAlterStorageSettings(desired.StorageSettings))
+	}
+	if desired.KeyBloomFilter != FeatureUnknown &&
+		desired.KeyBloomFilter != actual.KeyBloomFilter {
+		opts = append(opts, WithAlterKeyBloomFilter(desired.KeyBloomFilter))
+	}
+
+	if len(impossible) > 0 {
+		err = fmt.Errorf("%w: %s", ErrImpossibleAlter, strings.Join(impossible, "; "))
+	}
+	return opts, err
+}
+
+func withAddIndexDescription(idx IndexDescription) AlterTableOption {
+	opts := []IndexOption{
+		WithIndexColumns(idx.IndexColumns...),
+		WithDataColumns(idx.DataColumns...),
+	}
+	if idx.Type != nil {
+		opts = append(opts, WithIndexType(idx.Type))
+	}
+	return WithAddIndex(idx.Name, opts...)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func typeString(t types.Type) string {
+	var buf bytes.Buffer
+	value.WriteTypeStringTo(&buf, t)
+	return buf.String()
+}
@@ -0,0 +1,206 @@
+package options
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestDiff(t *testing.T) {
+	actual := Description{
+		Columns: []Column{
+			{Name: "id", Type: types.Optional(types.TypeUint64)},
+			{Name: "title", Type: types.Optional(types.TypeUTF8)},
+			{Name: "obsolete", Type: types.Optional(types.TypeString)},
+		},
+		PrimaryKey: []string{"id"},
+		ColumnFamilies: []ColumnFamily{
+			{Name: "default", Compression: ColumnFamilyCompressionNone},
+		},
+		Attributes: map[string]string{
+			"a": "1",
+			"b": "2",
+		},
+		TimeToLiveSettings: &TimeToLiveSettings{
+			ColumnName:         "created_at",
+			ExpireAfterSeconds: 60,
+		},
+	}
+	desired := Description{
+		Columns: []Column{
+			{Name: "id", Type: types.Optional(types.TypeUint64)},
+			{Name: "title", Type: types.Optional(types.TypeUTF8), Family: "cold"},
+			{Name: "created_at", Type: types.Optional(types.TypeTimestamp)},
+		},
+		PrimaryKey: []string{"id"},
+		ColumnFamilies: []ColumnFamily{
+			{Name: "default", Compression: ColumnFamilyCompressionLZ4},
+			{Name: "cold", Data: StoragePool{Media: "hdd"}},
+		},
+		Attributes: map[string]string{
+			"a": "10",
+		},
+		PartitioningSettings: PartitioningSettings{
+			PartitioningBySize: FeatureEnabled,
+			PartitionSizeMb:    1024,
+		},
+		KeyBloomFilter: FeatureEnabled,
+	}
+	opts, err := Diff(actual, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var d AlterTableDesc
+	for _, opt := range opts {
+		opt(&d)
+	}
+	if len(d.AddColumns) != 1 || d.AddColumns[0].Name != "created_at" {
+		t.Errorf("unexpected add columns: %v", d.AddColumns)
+	}
+	if len(d.DropColumns) != 1 || d.DropColumns[0] != "obsolete" {
+		t.Errorf("unexpected drop columns: %v", d.DropColumns)
+	}
+	if len(d.AlterColumns) != 1 || d.AlterColumns[0].Family != "cold" {
+		t.Errorf("unexpected alter columns: %v", d.AlterColumns)
+	}
+	if len(d.AddColumnFamilies) != 1 || d.AddColumnFamilies[0].Name != "cold" {
+		t.Errorf("unexpected add column families: %v", d.AddColumnFamilies)
+	}
+	if len(d.AlterColumnFamilies) != 1 ||
+		d.AlterColumnFamilies[0].Compression != Ydb_Table.ColumnFamily_COMPRESSION_LZ4 {
+		t.Errorf("unexpected alter column families: %v", d.AlterColumnFamilies)
+	}
+	if len(d.AlterAttributes) != 2 || d.AlterAttributes["a"] != "10" || d.AlterAttributes["b"] != "" {
+		t.Errorf("unexpected alter attributes: %v", d.AlterAttributes)
+	}
+	if _, ok := d.TtlAction.(*Ydb_Table.AlterTableRequest_DropTtlSettings); !ok {
+		t.Errorf("unexpected ttl action: %v", d.TtlAction)
+	}
+	if d.AlterPartitioningSettings.GetPartitionSizeMb() != 1024 {
+		t.Errorf("unexpected partitioning settings: %v", d.AlterPartitioningSettings)
+	}
+	if d.SetKeyBloomFilter != FeatureEnabled.ToYDB() {
+		t.Errorf("unexpected key bloom filter: %v", d.SetKeyBloomFilter)
+	}
+	if d.SetReadReplicasSettings != nil || d.AlterStorageSettings != nil {
+		t.Errorf("unexpected alter of unspecified settings")
+	}
+}
+
+func TestDiffEqual(t *testing.T) {
+	desc := Description{
+		Columns: []Column{
+			{Name: "id", Type: types.Optional(types.TypeUint64)},
+		},
+		PrimaryKey: []string{"id"},
+	}
+	opts, err := Diff(desc, desc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(opts) != 0 {
+		t.Fatalf("unexpected options: %d", len(opts))
+	}
+}
+
+func TestDiffUnspecifiedColumnFamilies(t *testing.T) {
+	// Description as it is returned from DescribeTable.
+	actual := Description{
+		Columns: []Column{
+			{Name: "id", Type: types.Optional(types.TypeUint64), Family: "default"},
+			{Name: "title", Type: types.Optional(types.TypeUTF8), Family: "default"},
+		},
+		PrimaryKey: []string{"id"},
+		ColumnFamilies: []ColumnFamily{
+			{Name: "default", Compression: ColumnFamilyCompressionNone},
+		},
+	}
+	desired := Description{
+		Columns: []Column{
+			{Name: "id", Type: types.Optional(types.TypeUint64)},
+			{Name: "title", Type: types.Optional(types.TypeUTF8)},
+			{Name: "created_at", Type: types.Optional(types.TypeTimestamp)},
+		},
+		PrimaryKey: []string{"id"},
+	}
+	opts, err := Diff(actual, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var d AlterTableDesc
+	for _, opt := range opts {
+		opt(&d)
+	}
+	if len(d.AddColumns) != 1 || len(d.AlterColumns) != 0 ||
+		len(d.AddColumnFamilies) != 0 || len(d.AlterColumnFamilies) != 0 {
+		t.Fatalf("unexpected alter: %v", &d)
+	}
+	desired.ColumnFamilies = []ColumnFamily{}
+	if _, err = Diff(actual, desired); !errors.Is(err, ErrImpossibleAlter) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDiffImpossible(t *testing.T) {
+	actual := Description{
+		Columns: []Column{
+			{Name: "id", Type: types.Optional(types.TypeUint64)},
+			{Name: "value", Type: types.Optional(types.TypeUTF8)},
+		},
+		PrimaryKey: []string{"id"},
+	}
+	desired := Description{
+		Columns: []Column{
+			{Name: "id", Type: types.Optional(types.TypeUint64)},
+			{Name: "value", Type: types.Optional(types.TypeInt64)},
+			{Name: "extra", Type: types.Optional(types.TypeInt64)},
+		},
+		PrimaryKey: []string{"id", "value"},
+	}
+	opts, err := Diff(actual, desired)
+	if !errors.Is(err, ErrImpossibleAlter) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(opts) != 1 {
+		t.Fatalf("unexpected options: %d", len(opts))
+	}
+}
+
+func TestDiffIndexes(t *testing.T) {
+	actual := Description{
+		PrimaryKey: []string{"id"},
+		Indexes: []IndexDescription{
+			{Name: "by_a", IndexColumns: []string{"a"}, Type: GlobalIndex()},
+			{Name: "by_b", IndexColumns: []string{"b"}, Type: GlobalIndex()},
+		},
+	}
+	desired := Description{
+		PrimaryKey: []string{"id"},
+		Indexes: []IndexDescription{
+			{Name: "by_a", IndexColumns: []string{"a"}},
+			{Name: "by_c", IndexColumns: []string{"c"}, Type: GlobalAsyncIndex()},
+		},
+	}
+	opts, err := Diff(actual, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var d AlterTableDesc
+	for _, opt := range opts {
+		opt(&d)
+	}
+	if len(d.AddIndexes) != 1 || d.AddIndexes[0].Name != "by_c" || d.AddIndexes[0].GetGlobalAsyncIndex() == nil {
+		t.Errorf("unexpected add indexes: %v", d.AddIndexes)
+	}
+	if len(d.DropIndexes) != 1 || d.DropIndexes[0] != "by_b" {
+		t.Errorf("unexpected drop indexes: %v", d.DropIndexes)
+	}
+
+	desired.Indexes[0].IndexColumns = []string{"a", "b"}
+	if _, err = Diff(actual, desired); !errors.Is(err, ErrImpossibleAlter) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 type FeatureFlag = feature.Flag
 
 const (
+	FeatureUnknown  = feature.FeatureUnknown
 	FeatureEnabled  = feature.FeatureEnabled
 	FeatureDisabled = feature.FeatureDisabled
 )
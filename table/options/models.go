@@ -54,19 +54,95 @@ func NewTableColumn(name string, typ types.Type, family string) Column {
 	}
 }
 
+type IndexStatus byte
+
+const (
+	IndexStatusUnknown IndexStatus = iota
+	IndexStatusReady
+	IndexStatusBuilding
+)
+
+func (s IndexStatus) String() string {
+	switch s {
+	case IndexStatusReady:
+		return "ready"
+	case IndexStatusBuilding:
+		return "building"
+	default:
+		return statusUnknown
+	}
+}
+
+func (s IndexStatus) toYDB() Ydb_Table.TableIndexDescription_Status {
+	switch s {
+	case IndexStatusReady:
+		return Ydb_Table.TableIndexDescription_STATUS_READY
+	case IndexStatusBuilding:
+		return Ydb_Table.TableIndexDescription_STATUS_BUILDING
+	default:
+		return Ydb_Table.TableIndexDescription_STATUS_UNSPECIFIED
+	}
+}
+
+func indexStatus(s Ydb_Table.TableIndexDescription_Status) IndexStatus {
+	switch s {
+	case Ydb_Table.TableIndexDescription_STATUS_READY:
+		return IndexStatusReady
+	case Ydb_Table.TableIndexDescription_STATUS_BUILDING:
+		return IndexStatusBuilding
+	default:
+		return IndexStatusUnknown
+	}
+}
+
 type IndexDescription struct {
 	Name         string
 	IndexColumns []string
+	DataColumns  []string
+	Type         IndexType
 	Status       Ydb_Table.TableIndexDescription_Status
 }
 
+// IndexStatus returns status of the index.
+func (i IndexDescription) IndexStatus() IndexStatus {
+	return indexStatus(i.Status)
+}
+
 // nolint:unused
 func (i IndexDescription) toYDB() *Ydb_Table.TableIndexDescription {
-	return &Ydb_Table.TableIndexDescription{
+	d := &Ydb_Table.TableIndexDescription{
 		Name:         i.Name,
 		IndexColumns: i.IndexColumns,
+		DataColumns:  i.DataColumns,
 		Status:       i.Status,
 	}
+	if i.Type != nil {
+		x := indexDesc{}
+		i.Type.setup(&x)
+		switch t := x.Type.(type) {
+		case *Ydb_Table.TableIndex_GlobalIndex:
+			d.Type = &Ydb_Table.TableIndexDescription_GlobalIndex{GlobalIndex: t.GlobalIndex}
+		case *Ydb_Table.TableIndex_GlobalAsyncIndex:
+			d.Type = &Ydb_Table.TableIndexDescription_GlobalAsyncIndex{GlobalAsyncIndex: t.GlobalAsyncIndex}
+		}
+	}
+	return d
+}
+
+func NewIndexDescription(idx *Ydb_Table.TableIndexDescription) IndexDescription {
+	d := IndexDescription{
+		Name:         idx.GetName(),
+		IndexColumns: idx.GetIndexColumns(),
+		DataColumns:  idx.GetDataColumns(),
+		Status:       idx.GetStatus(),
+	}
+	switch idx.GetType().(type) {
+	case *Ydb_Table.TableIndexDescription_GlobalIndex:
+		d.Type = GlobalIndex()
+	case *Ydb_Table.TableIndexDescription_GlobalAsyncIndex:
+		d.Type = GlobalAsyncIndex()
+	}
+	return d
 }
 
 type Description struct {
@@ -104,6 +104,12 @@ func WithIndexColumns(columns ...string) IndexOption {
 	}
 }
 
+func WithDataColumns(columns ...string) IndexOption {
+	return func(d *indexDesc) {
+		d.DataColumns = append(d.DataColumns, columns...)
+	}
+}
+
 func WithIndexType(t IndexType) IndexOption {
 	return func(d *indexDesc) {
 		t.setup(d)
@@ -393,6 +399,11 @@ func WithAlterAttribute(key, value string) AlterTableOption {
 	}
 }
 
+// WithDropAttribute drops attribute by setting empty value
+func WithDropAttribute(key string) AlterTableOption {
+	return WithAlterAttribute(key, "")
+}
+
 func WithAddColumnMeta(column Column) AlterTableOption {
 	return func(d *AlterTableDesc) {
 		d.AddColumns = append(d.AddColumns, column.toYDB())
@@ -405,20 +416,45 @@ func WithDropColumn(name string) AlterTableOption {
 	}
 }
 
+func WithSetColumnFamily(column, family string) AlterTableOption {
+	return func(d *AlterTableDesc) {
+		d.AlterColumns = append(d.AlterColumns, &Ydb_Table.ColumnMeta{
+			Name:   column,
+			Family: family,
+		})
+	}
+}
+
+func WithAddIndex(name string, opts ...IndexOption) AlterTableOption {
+	return func(d *AlterTableDesc) {
+		x := &Ydb_Table.TableIndex{
+			Name: name,
+		}
+		for _, opt := range opts {
+			opt((*indexDesc)(x))
+		}
+		d.AddIndexes = append(d.AddIndexes, x)
+	}
+}
+
+func WithDropIndex(name string) AlterTableOption {
+	return func(d *AlterTableDesc) {
+		d.DropIndexes = append(d.DropIndexes, name)
+	}
+}
+
 func WithAddColumnFamilies(cf ...ColumnFamily) AlterTableOption {
 	return func(d *AlterTableDesc) {
-		d.AddColumnFamilies = make([]*Ydb_Table.ColumnFamily, len(cf))
-		for i, c := range cf {
-			d.AddColumnFamilies[i] = c.toYDB()
+		for _, c := range cf {
+			d.AddColumnFamilies = append(d.AddColumnFamilies, c.toYDB())
 		}
 	}
 }
 
 func WithAlterColumnFamilies(cf ...ColumnFamily) AlterTableOption {
 	return func(d *AlterTableDesc) {
-		d.AddColumnFamilies = make([]*Ydb_Table.ColumnFamily, len(cf))
-		for i, c := range cf {
-			d.AddColumnFamilies[i] = c.toYDB()
+		for _, c := range cf {
+			d.AlterColumnFamilies = append(d.AlterColumnFamilies, c.toYDB())
 		}
 	}
 }
@@ -538,6 +574,30 @@ func WithExecuteScanQueryMode(m ExecuteScanQueryRequestMode) ExecuteScanQueryOpt
 	}
 }
 
+// WithExecuteScanQueryCollectStatsModeNone disables collection of statistics
+// of the query.
+func WithExecuteScanQueryCollectStatsModeNone() ExecuteScanQueryOption {
+	return func(desc *ExecuteScanQueryDesc) {
+		desc.CollectStats = Ydb_Table.QueryStatsCollection_STATS_COLLECTION_NONE
+	}
+}
+
+// WithExecuteScanQueryCollectStatsModeBasic makes server collect basic
+// statistics of the query, such as phases, rows and bytes read and written.
+func WithExecuteScanQueryCollectStatsModeBasic() ExecuteScanQueryOption {
+	return func(desc *ExecuteScanQueryDesc) {
+		desc.CollectStats = Ydb_Table.QueryStatsCollection_STATS_COLLECTION_BASIC
+	}
+}
+
+// WithExecuteScanQueryCollectStatsModeFull makes server collect execution
+// statistics and plan of the query in addition to basic statistics.
+func WithExecuteScanQueryCollectStatsModeFull() ExecuteScanQueryOption {
+	return func(desc *ExecuteScanQueryDesc) {
+		desc.CollectStats = Ydb_Table.QueryStatsCollection_STATS_COLLECTION_FULL
+	}
+}
+
 // Read table options
 type (
 	ReadTableDesc   Ydb_Table.ReadTableRequest
@@ -195,11 +195,11 @@ func TestAlterTableOptions(t *testing.T) {
 		opt := WithAlterColumnFamilies(cf)
 		req := Ydb_Table.AlterTableRequest{}
 		opt((*AlterTableDesc)(&req))
-		if len(req.AddColumnFamilies) != 1 ||
-			req.AddColumnFamilies[0].Name != cf.Name ||
-			req.AddColumnFamilies[0].Data.Media != cf.Data.Media ||
-			req.AddColumnFamilies[0].Compression != cf.Compression.toYDB() ||
-			req.AddColumnFamilies[0].KeepInMemory != cf.KeepInMemory.ToYDB() {
+		if len(req.AlterColumnFamilies) != 1 ||
+			req.AlterColumnFamilies[0].Name != cf.Name ||
+			req.AlterColumnFamilies[0].Data.Media != cf.Data.Media ||
+			req.AlterColumnFamilies[0].Compression != cf.Compression.toYDB() ||
+			req.AlterColumnFamilies[0].KeepInMemory != cf.KeepInMemory.ToYDB() {
 			t.Errorf("Alter table options is not as expected")
 		}
 	}
@@ -211,11 +211,11 @@ func TestAlterTableOptions(t *testing.T) {
 		opt := WithAlterColumnFamilies(cf)
 		req := Ydb_Table.AlterTableRequest{}
 		opt((*AlterTableDesc)(&req))
-		if len(req.AddColumnFamilies) != 1 ||
-			req.AddColumnFamilies[0].Name != cf.Name ||
-			req.AddColumnFamilies[0].Data != nil ||
-			req.AddColumnFamilies[0].Compression != cf.Compression.toYDB() ||
-			req.AddColumnFamilies[0].KeepInMemory != Ydb.FeatureFlag_STATUS_UNSPECIFIED {
+		if len(req.AlterColumnFamilies) != 1 ||
+			req.AlterColumnFamilies[0].Name != cf.Name ||
+			req.AlterColumnFamilies[0].Data != nil ||
+			req.AlterColumnFamilies[0].Compression != cf.Compression.toYDB() ||
+			req.AlterColumnFamilies[0].KeepInMemory != Ydb.FeatureFlag_STATUS_UNSPECIFIED {
 			t.Errorf("Alter table options is not as expected")
 		}
 	}
@@ -248,3 +248,54 @@ func TestAlterTableOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestAlterTableIndexOptions(t *testing.T) {
+	{
+		opt := WithAddIndex("idx",
+			WithIndexColumns("a", "b"),
+			WithDataColumns("c"),
+			WithIndexType(GlobalAsyncIndex()),
+		)
+		req := Ydb_Table.AlterTableRequest{}
+		opt((*AlterTableDesc)(&req))
+		if len(req.AddIndexes) != 1 ||
+			req.AddIndexes[0].Name != "idx" ||
+			len(req.AddIndexes[0].IndexColumns) != 2 ||
+			len(req.AddIndexes[0].DataColumns) != 1 ||
+			req.AddIndexes[0].GetGlobalAsyncIndex() == nil {
+			t.Errorf("Alter table options is not as expected")
+		}
+	}
+	{
+		opt := WithDropIndex("idx")
+		req := Ydb_Table.AlterTableRequest{}
+		opt((*AlterTableDesc)(&req))
+		if len(req.DropIndexes) != 1 || req.DropIndexes[0] != "idx" {
+			t.Errorf("Alter table options is not as expected")
+		}
+	}
+}
+
+func TestIndexDescription(t *testing.T) {
+	for _, status := range []IndexStatus{
+		IndexStatusUnknown,
+		IndexStatusReady,
+		IndexStatusBuilding,
+	} {
+		idx := IndexDescription{
+			Name:         "idx",
+			IndexColumns: []string{"a"},
+			DataColumns:  []string{"b"},
+			Type:         GlobalIndex(),
+			Status:       status.toYDB(),
+		}
+		act := NewIndexDescription(idx.toYDB())
+		if act.Name != idx.Name ||
+			act.IndexStatus() != status ||
+			act.Type != idx.Type ||
+			len(act.IndexColumns) != 1 ||
+			len(act.DataColumns) != 1 {
+			t.Errorf("Index description is not as expected: %+v", act)
+		}
+	}
+}
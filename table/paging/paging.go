@@ -0,0 +1,381 @@
+// Package paging reads all rows of a data query regardless of the limit of
+// rows in a result set of Session.Execute.
+//
+// If result set of the query is truncated, Execute continues reading with
+// key-based pagination: the query is executed again for rows following the
+// last row received, in order of given key columns:
+//
+//     rows, err := paging.Execute(ctx, s,
+//         "SELECT series_id, episode_id, title FROM episodes WHERE views > $views",
+//         table.NewQueryParameters(
+//             table.ValueParam("$views", types.Uint64Value(1000)),
+//         ),
+//         paging.WithKeys("series_id", "episode_id"),
+//     )
+//     if err != nil {
+//         // handle error
+//     }
+//     defer rows.Close()
+//     for rows.Next(ctx) {
+//         var (
+//             seriesID  *uint64
+//             episodeID *uint64
+//             title     *string
+//         )
+//         if err = rows.Scan(&seriesID, &episodeID, &title); err != nil {
+//             // handle error
+//         }
+//     }
+//     if err = rows.Err(); err != nil {
+//         // handle error
+//     }
+//
+// Pages of the query above are executed as follows, where declarations of
+// $paging_key0 and $paging_key1 and the WHERE clause are added for pages
+// after the first one. PRAGMA OrderedColumns keeps order of columns of the
+// query in SELECT * of pages:
+//
+//     PRAGMA OrderedColumns;
+//     DECLARE $views AS Uint64;
+//     DECLARE $paging_key0 AS Optional<Uint64>;
+//     DECLARE $paging_key1 AS Optional<Uint64>;
+//     $paging_source = (SELECT series_id, episode_id, title FROM episodes WHERE views > $views);
+//     SELECT * FROM $paging_source
+//     WHERE `series_id` > $paging_key0 OR (`series_id` = $paging_key0 AND (`episode_id` > $paging_key1))
+//     ORDER BY `series_id`, `episode_id`;
+//
+// So the query must be a single SELECT statement, its columns must contain
+// the key columns and the key columns must identify rows.
+//
+// Without key columns a truncated result of the query is discarded and the
+// query is executed with Session.StreamExecuteScanQuery instead. In this case
+// rows are not ordered unless the query has ORDER BY clause.
+//
+// In both cases the query must not declare parameters: they are always
+// declared from values of parameters.
+package paging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/query"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/resultset"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+var (
+	// ErrNullKey is returned if a key column of the last row of a truncated
+	// page is NULL, so the next page cannot be selected.
+	ErrNullKey = errors.New("ydb: paging: NULL key of truncated page")
+
+	errNoRawResult = errors.New("ydb: paging: result sets are not available")
+)
+
+type config struct {
+	keys []string
+	txc  *table.TransactionControl
+	exec []options.ExecuteDataQueryOption
+	scan []options.ExecuteScanQueryOption
+}
+
+type Option func(c *config)
+
+// WithKeys sets key columns for pagination of truncated results.
+// Keys must identify rows of the query, e.g. primary key columns of the
+// table.
+func WithKeys(keys ...string) Option {
+	return func(c *config) {
+		c.keys = append(c.keys, keys...)
+	}
+}
+
+// WithTxControl sets transaction control of the first page.
+// If the first page begins transaction without committing it, the next pages
+// are executed within the same transaction and the caller must commit or
+// roll back Rows.Tx(). Otherwise, each page is executed in a separate
+// transaction.
+// Default is online read-only transaction committed with the first page.
+func WithTxControl(txc *table.TransactionControl) Option {
+	return func(c *config) {
+		c.txc = txc
+	}
+}
+
+// WithExecuteOptions sets options of Session.Execute calls.
+func WithExecuteOptions(opts ...options.ExecuteDataQueryOption) Option {
+	return func(c *config) {
+		c.exec = append(c.exec, opts...)
+	}
+}
+
+// WithScanOptions sets options of Session.StreamExecuteScanQuery call.
+func WithScanOptions(opts ...options.ExecuteScanQueryOption) Option {
+	return func(c *config) {
+		c.scan = append(c.scan, opts...)
+	}
+}
+
+// rawResult is implemented by results of the table client and gives access to
+// result sets without scanning.
+type rawResult interface {
+	RawResultSet() *Ydb.ResultSet
+}
+
+// Rows is an iterator over rows of all pages of the query.
+type Rows struct {
+	s      table.Session
+	text   string
+	params *table.QueryParameters
+	config
+
+	tx        table.Transaction
+	res       resultset.Result
+	set       *Ydb.ResultSet
+	pages     int
+	streaming bool
+	err       error
+}
+
+// Execute executes the first page of the query and returns iterator over
+// rows of the query. If result set of the first page is truncated and no keys
+// are given, the query is executed with Session.StreamExecuteScanQuery.
+func Execute(
+	ctx context.Context, s table.Session, text string, params *table.QueryParameters, opts ...Option,
+) (*Rows, error) {
+	r := &Rows{
+		s:      s,
+		text:   text,
+		params: params,
+	}
+	for _, opt := range opts {
+		opt(&r.config)
+	}
+	if r.txc == nil {
+		r.txc = table.TxControl(
+			table.BeginTx(table.WithOnlineReadOnly()),
+			table.CommitTx(),
+		)
+	}
+	if err := r.page(ctx, nil); err != nil {
+		return nil, err
+	}
+	if !r.set.GetTruncated() || len(r.keys) > 0 {
+		return r, nil
+	}
+	_ = r.res.Close()
+	res, err := s.StreamExecuteScanQuery(ctx, r.declarations()+text, params, r.scan...)
+	if err != nil {
+		return nil, err
+	}
+	r.res, r.set, r.streaming = res, nil, true
+	return r, nil
+}
+
+// page executes query for rows following the key and selects its result set.
+func (r *Rows) page(ctx context.Context, key []types.Value) error {
+	text, params := r.query(key)
+	txc := r.txc
+	if r.tx != nil && r.tx.IsActive() {
+		txc = table.TxControl(table.WithTx(r.tx))
+	}
+	tx, res, err := r.s.Execute(ctx, txc, text, params, r.exec...)
+	if err != nil {
+		return err
+	}
+	r.tx, r.res = tx, res
+	r.pages++
+	if !res.NextResultSet(ctx) {
+		if err = res.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("ydb: paging: query has no result sets")
+	}
+	raw, ok := res.(rawResult)
+	if !ok {
+		return errNoRawResult
+	}
+	r.set = raw.RawResultSet()
+	return nil
+}
+
+// declarations returns declarations of parameters of the query in order of
+// their names.
+func (r *Rows) declarations() string {
+	var (
+		buf   strings.Builder
+		names []string
+		decls = make(map[string]string)
+	)
+	r.params.Each(func(name string, v types.Value) {
+		names = append(names, name)
+		decls[name] = fmt.Sprintf("DECLARE %s AS %s;\n", name, value.FormatTypeYQL(types.TypeOf(v)))
+	})
+	sort.Strings(names)
+	for _, name := range names {
+		buf.WriteString(decls[name])
+	}
+	return buf.String()
+}
+
+// query returns text and parameters of the query for rows following the key.
+// Without key columns it returns the query with declarations of parameters.
+// Parameters are declared in order of their names, so text of the query is
+// the same for all pages except the first one.
+func (r *Rows) query(key []types.Value) (string, *table.QueryParameters) {
+	if len(r.keys) == 0 {
+		return r.declarations() + r.text, r.params
+	}
+	var (
+		buf    strings.Builder
+		params = table.NewQueryParameters()
+	)
+	buf.WriteString("PRAGMA OrderedColumns;\n")
+	buf.WriteString(r.declarations())
+	r.params.Each(func(name string, v types.Value) {
+		params.Add(table.ValueParam(name, v))
+	})
+	for i, v := range key {
+		name := fmt.Sprintf("$paging_key%d", i)
+		fmt.Fprintf(&buf, "DECLARE %s AS %s;\n", name, value.FormatTypeYQL(types.TypeOf(v)))
+		params.Add(table.ValueParam(name, v))
+	}
+	fmt.Fprintf(&buf, "$paging_source = (%s);\n", strings.TrimRight(strings.TrimSpace(r.text), ";"))
+	buf.WriteString("SELECT * FROM $paging_source")
+	if len(key) > 0 {
+		buf.WriteString("\nWHERE ")
+		writeAfter(&buf, r.keys, 0)
+	}
+	buf.WriteString("\nORDER BY ")
+	for i, k := range r.keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(query.Quote(k))
+	}
+	buf.WriteByte(';')
+	return buf.String(), params
+}
+
+// writeAfter writes condition of rows following the key in order of keys
+// starting from i-th key.
+func writeAfter(buf *strings.Builder, keys []string, i int) {
+	fmt.Fprintf(buf, "%s > $paging_key%d", query.Quote(keys[i]), i)
+	if i+1 < len(keys) {
+		fmt.Fprintf(buf, " OR (%s = $paging_key%d AND (", query.Quote(keys[i]), i)
+		writeAfter(buf, keys, i+1)
+		buf.WriteString("))")
+	}
+}
+
+// lastKey returns values of key columns of the last row of the current page.
+func (r *Rows) lastKey() ([]types.Value, error) {
+	rows := r.set.GetRows()
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("ydb: paging: truncated page has no rows")
+	}
+	last := rows[len(rows)-1].GetItems()
+	key := make([]types.Value, len(r.keys))
+	for i, k := range r.keys {
+		j := columnIndex(r.set.GetColumns(), k)
+		if j < 0 {
+			return nil, fmt.Errorf("ydb: paging: key column %q not found", k)
+		}
+		v := value.FromYDB(r.set.GetColumns()[j].GetType(), last[j])
+		if _, isOptional := types.TypeOf(v).(value.OptionalType); isOptional {
+			if _, ok, _ := types.OptionalItem(v); !ok {
+				return nil, ErrNullKey
+			}
+		}
+		key[i] = v
+	}
+	return key, nil
+}
+
+func columnIndex(columns []*Ydb.Column, name string) int {
+	for i, c := range columns {
+		if c.GetName() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Next advances to the next row, executing next page of the query if the
+// current one is exhausted and truncated. It returns false if there are no
+// more rows or an error occurred.
+func (r *Rows) Next(ctx context.Context) bool {
+	if r.err != nil {
+		return false
+	}
+	for {
+		if r.res.NextRow() {
+			return true
+		}
+		if r.err = r.res.Err(); r.err != nil {
+			return false
+		}
+		if r.streaming {
+			if !r.res.NextResultSet(ctx) {
+				r.err = r.res.Err()
+				return false
+			}
+			continue
+		}
+		if !r.set.GetTruncated() {
+			return false
+		}
+		var key []types.Value
+		if key, r.err = r.lastKey(); r.err != nil {
+			return false
+		}
+		_ = r.res.Close()
+		if r.err = r.page(ctx, key); r.err != nil {
+			return false
+		}
+	}
+}
+
+// Scan scans values of the current row as resultset.Result.Scan does.
+func (r *Rows) Scan(values ...interface{}) error {
+	return r.res.Scan(values...)
+}
+
+// ScanWithDefaults scans values of the current row as
+// resultset.Result.ScanWithDefaults does.
+func (r *Rows) ScanWithDefaults(values ...interface{}) error {
+	return r.res.ScanWithDefaults(values...)
+}
+
+// CurrentResultSet returns result set of the current page.
+func (r *Rows) CurrentResultSet() resultset.ResultSet {
+	return r.res.CurrentResultSet()
+}
+
+// Pages returns number of executed data queries.
+func (r *Rows) Pages() int {
+	return r.pages
+}
+
+// Tx returns transaction of the last executed page.
+func (r *Rows) Tx() table.Transaction {
+	return r.tx
+}
+
+// Err returns error encountered during iteration.
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Close closes result of the current page.
+func (r *Rows) Close() error {
+	return r.res.Close()
+}
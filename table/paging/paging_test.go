@@ -0,0 +1,246 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/resultset"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type session struct {
+	table.Session
+
+	pages   []*Ydb.ResultSet
+	scan    []*Ydb.ResultSet
+	queries []string
+	params  []*table.QueryParameters
+	scanned bool
+	// scanQuery is a text of scan query.
+	scanQuery string
+}
+
+func (s *session) Execute(
+	_ context.Context, _ *table.TransactionControl, query string, params *table.QueryParameters,
+	_ ...options.ExecuteDataQueryOption,
+) (table.Transaction, resultset.Result, error) {
+	s.queries = append(s.queries, query)
+	s.params = append(s.params, params)
+	set := s.pages[0]
+	s.pages = s.pages[1:]
+	return nil, &scanner.Result{Sets: []*Ydb.ResultSet{set}}, nil
+}
+
+func (s *session) StreamExecuteScanQuery(
+	_ context.Context, query string, _ *table.QueryParameters, _ ...options.ExecuteScanQueryOption,
+) (resultset.Result, error) {
+	s.scanned = true
+	s.scanQuery = query
+	return &scanner.Result{Sets: s.scan}, nil
+}
+
+var columns = []*Ydb.Column{
+	{Name: "id", Type: value.TypeToYDB(types.Optional(types.TypeUint64))},
+	{Name: "title", Type: value.TypeToYDB(types.TypeUTF8)},
+}
+
+func resultSet(truncated bool, ids ...types.Value) *Ydb.ResultSet {
+	set := &Ydb.ResultSet{
+		Columns:   columns,
+		Truncated: truncated,
+	}
+	for _, id := range ids {
+		set.Rows = append(set.Rows, &Ydb.Value{Items: []*Ydb.Value{
+			id.ToYDB().Value,
+			types.UTF8Value("a").ToYDB().Value,
+		}})
+	}
+	return set
+}
+
+func id(v uint64) types.Value {
+	return types.OptionalValue(types.Uint64Value(v))
+}
+
+func readIDs(t *testing.T, r *Rows) (ids []uint64) {
+	t.Helper()
+	for r.Next(context.Background()) {
+		var (
+			id    *uint64
+			title string
+		)
+		if err := r.Scan(&id, &title); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, *id)
+	}
+	return ids
+}
+
+func TestExecuteKeys(t *testing.T) {
+	s := &session{
+		pages: []*Ydb.ResultSet{
+			resultSet(true, id(1), id(2)),
+			resultSet(false, id(3)),
+		},
+	}
+	r, err := Execute(context.Background(), s,
+		"SELECT id, title FROM series WHERE title != $title;",
+		table.NewQueryParameters(
+			table.ValueParam("$title", types.UTF8Value("b")),
+		),
+		WithKeys("id"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	ids := readIDs(t, r)
+	if err = r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+	if r.Pages() != 2 || s.scanned {
+		t.Fatalf("unexpected pages: %d, scanned: %t", r.Pages(), s.scanned)
+	}
+	for i, exp := range []string{
+		"PRAGMA OrderedColumns;\n" +
+			"DECLARE $title AS Utf8;\n" +
+			"$paging_source = (SELECT id, title FROM series WHERE title != $title);\n" +
+			"SELECT * FROM $paging_source\n" +
+			"ORDER BY `id`;",
+		"PRAGMA OrderedColumns;\n" +
+			"DECLARE $title AS Utf8;\n" +
+			"DECLARE $paging_key0 AS Optional<Uint64>;\n" +
+			"$paging_source = (SELECT id, title FROM series WHERE title != $title);\n" +
+			"SELECT * FROM $paging_source\n" +
+			"WHERE `id` > $paging_key0\n" +
+			"ORDER BY `id`;",
+	} {
+		if s.queries[i] != exp {
+			t.Errorf("unexpected query of page %d:\n%s\nexp:\n%s", i, s.queries[i], exp)
+		}
+	}
+	var key types.Value
+	s.params[1].Each(func(name string, v types.Value) {
+		if name == "$paging_key0" {
+			key = v
+		}
+	})
+	item, ok, err := types.OptionalItem(key)
+	if err != nil || !ok {
+		t.Fatalf("unexpected key: %v", key)
+	}
+	if x, err := types.AsUint64(item); err != nil || x != 2 {
+		t.Fatalf("unexpected key: %v", key)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	r := &Rows{
+		text:   "SELECT 1",
+		config: config{keys: []string{"a", "b", "c"}},
+	}
+	q, _ := r.query([]types.Value{id(1), id(2), id(3)})
+	exp := "PRAGMA OrderedColumns;\n" +
+		"DECLARE $paging_key0 AS Optional<Uint64>;\n" +
+		"DECLARE $paging_key1 AS Optional<Uint64>;\n" +
+		"DECLARE $paging_key2 AS Optional<Uint64>;\n" +
+		"$paging_source = (SELECT 1);\n" +
+		"SELECT * FROM $paging_source\n" +
+		"WHERE `a` > $paging_key0 OR (`a` = $paging_key0 AND (`b` > $paging_key1 OR (`b` = $paging_key1 AND (`c` > $paging_key2))))\n" +
+		"ORDER BY `a`, `b`, `c`;"
+	if q != exp {
+		t.Fatalf("unexpected query:\n%s\nexp:\n%s", q, exp)
+	}
+}
+
+func TestExecuteNullKey(t *testing.T) {
+	s := &session{
+		pages: []*Ydb.ResultSet{
+			resultSet(true, id(1), types.NullValue(types.TypeUint64)),
+		},
+	}
+	r, err := Execute(context.Background(), s, "SELECT id, title FROM series", nil, WithKeys("id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	n := 0
+	for r.Next(context.Background()) {
+		n++
+	}
+	if n != 2 || !errors.Is(r.Err(), ErrNullKey) {
+		t.Fatalf("unexpected rows: %d, error: %v", n, r.Err())
+	}
+}
+
+func TestExecuteScan(t *testing.T) {
+	s := &session{
+		pages: []*Ydb.ResultSet{
+			resultSet(true, id(1)),
+		},
+		scan: []*Ydb.ResultSet{
+			resultSet(false, id(1), id(2)),
+			resultSet(false, id(3)),
+		},
+	}
+	r, err := Execute(context.Background(), s, "SELECT id, title FROM series", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	ids := readIDs(t, r)
+	if err = r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || !s.scanned || r.Pages() != 1 {
+		t.Fatalf("unexpected ids: %v, scanned: %t, pages: %d", ids, s.scanned, r.Pages())
+	}
+	if s.queries[0] != "SELECT id, title FROM series" {
+		t.Fatalf("unexpected query: %s", s.queries[0])
+	}
+}
+
+func TestExecuteParamsWithoutKeys(t *testing.T) {
+	s := &session{
+		pages: []*Ydb.ResultSet{
+			resultSet(true, id(1)),
+		},
+		scan: []*Ydb.ResultSet{
+			resultSet(false, id(1), id(2)),
+		},
+	}
+	r, err := Execute(context.Background(), s,
+		"SELECT id, title FROM series WHERE title != $title AND id > $id",
+		table.NewQueryParameters(
+			table.ValueParam("$title", types.UTF8Value("b")),
+			table.ValueParam("$id", types.Uint64Value(0)),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if ids := readIDs(t, r); len(ids) != 2 || r.Err() != nil {
+		t.Fatalf("unexpected ids: %v, error: %v", ids, r.Err())
+	}
+	exp := "DECLARE $id AS Uint64;\n" +
+		"DECLARE $title AS Utf8;\n" +
+		"SELECT id, title FROM series WHERE title != $title AND id > $id"
+	if s.queries[0] != exp {
+		t.Errorf("unexpected query:\n%s\nexp:\n%s", s.queries[0], exp)
+	}
+	if s.scanQuery != exp {
+		t.Errorf("unexpected scan query:\n%s\nexp:\n%s", s.scanQuery, exp)
+	}
+}
@@ -0,0 +1,427 @@
+// Package query builds YQL queries with parameters.
+//
+// Values are never inlined into query text: each of them becomes a declared
+// parameter named in order of appearance ($p0, $p1 and so on), so text of
+// the query depends only on its structure and server query cache is
+// effective for queries which differ by values only.
+//
+//     q, params, err := query.Select("users", "id", "name").
+//         Where(query.And(
+//             query.Eq("status", "active"),
+//             query.In("id", []uint64{1, 2, 3}),
+//         )).
+//         OrderByDesc("id").
+//         Limit(10).
+//         Build()
+//     if err != nil {
+//         // handle error
+//     }
+//     _, res, err := s.Execute(ctx, txc, q, params)
+//
+// Build of the query above returns following text:
+//
+//     DECLARE $p0 AS Utf8;
+//     DECLARE $p1 AS List<Uint64>;
+//     DECLARE $p2 AS Uint64;
+//     SELECT `id`, `name` FROM `users` WHERE `status` = $p0 AND `id` IN $p1 ORDER BY `id` DESC LIMIT $p2;
+//
+// Values are marshaled with types.Marshal, so they may be types.Value,
+// implementations of types.ValueMarshaler or Go values.
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// Quote returns identifier or table path quoted with backticks.
+func Quote(name string) string {
+	return "`" + strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(name) + "`"
+}
+
+type builder struct {
+	buf    strings.Builder
+	params []types.Value
+	err    error
+}
+
+func (b *builder) name(name string) {
+	b.buf.WriteString(Quote(name))
+}
+
+func (b *builder) names(names []string) {
+	for i, name := range names {
+		if i > 0 {
+			b.buf.WriteString(", ")
+		}
+		b.name(name)
+	}
+}
+
+// param writes name of a new parameter with value v.
+func (b *builder) param(v interface{}) {
+	x, err := types.Marshal(v)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return
+	}
+	fmt.Fprintf(&b.buf, "$p%d", len(b.params))
+	b.params = append(b.params, x)
+}
+
+func (b *builder) where(c Cond) {
+	if c != nil {
+		b.buf.WriteString(" WHERE ")
+		c.write(b, false)
+	}
+}
+
+// build returns text of query with declarations of parameters.
+func (b *builder) build() (string, *table.QueryParameters, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	var (
+		text   strings.Builder
+		params = table.NewQueryParameters()
+	)
+	for i, v := range b.params {
+		name := fmt.Sprintf("$p%d", i)
+		fmt.Fprintf(&text, "DECLARE %s AS %s;\n", name, value.FormatTypeYQL(types.TypeOf(v)))
+		params.Add(table.ValueParam(name, v))
+	}
+	text.WriteString(b.buf.String())
+	text.WriteByte(';')
+	return text.String(), params, nil
+}
+
+// Cond is a condition of WHERE clause.
+type Cond interface {
+	// write writes condition. If nested is true then the condition is written
+	// as operand of another condition.
+	write(b *builder, nested bool)
+}
+
+type condFunc func(b *builder, nested bool)
+
+func (f condFunc) write(b *builder, nested bool) {
+	f(b, nested)
+}
+
+func compare(column, op string, v interface{}) Cond {
+	return condFunc(func(b *builder, _ bool) {
+		b.name(column)
+		b.buf.WriteString(op)
+		b.param(v)
+	})
+}
+
+// Eq returns condition column = v.
+func Eq(column string, v interface{}) Cond { return compare(column, " = ", v) }
+
+// Ne returns condition column != v.
+func Ne(column string, v interface{}) Cond { return compare(column, " != ", v) }
+
+// Lt returns condition column < v.
+func Lt(column string, v interface{}) Cond { return compare(column, " < ", v) }
+
+// Le returns condition column <= v.
+func Le(column string, v interface{}) Cond { return compare(column, " <= ", v) }
+
+// Gt returns condition column > v.
+func Gt(column string, v interface{}) Cond { return compare(column, " > ", v) }
+
+// Ge returns condition column >= v.
+func Ge(column string, v interface{}) Cond { return compare(column, " >= ", v) }
+
+// Like returns condition column LIKE pattern.
+func Like(column string, pattern interface{}) Cond { return compare(column, " LIKE ", pattern) }
+
+// In returns condition column IN list, where list is a slice or List value.
+// The whole list is a single parameter, so text of the query does not depend
+// on the list length.
+func In(column string, list interface{}) Cond { return compare(column, " IN ", list) }
+
+// IsNull returns condition column IS NULL.
+func IsNull(column string) Cond {
+	return condFunc(func(b *builder, _ bool) {
+		b.name(column)
+		b.buf.WriteString(" IS NULL")
+	})
+}
+
+// IsNotNull returns condition column IS NOT NULL.
+func IsNotNull(column string) Cond {
+	return condFunc(func(b *builder, _ bool) {
+		b.name(column)
+		b.buf.WriteString(" IS NOT NULL")
+	})
+}
+
+func join(op string, conds []Cond) Cond {
+	return condFunc(func(b *builder, nested bool) {
+		if len(conds) == 1 {
+			conds[0].write(b, nested)
+			return
+		}
+		if nested {
+			b.buf.WriteByte('(')
+		}
+		for i, c := range conds {
+			if i > 0 {
+				b.buf.WriteString(op)
+			}
+			c.write(b, true)
+		}
+		if nested {
+			b.buf.WriteByte(')')
+		}
+	})
+}
+
+// And returns conjunction of conditions. And of no conditions is true.
+func And(conds ...Cond) Cond {
+	if len(conds) == 0 {
+		return condFunc(func(b *builder, _ bool) {
+			b.buf.WriteString("true")
+		})
+	}
+	return join(" AND ", conds)
+}
+
+// Or returns disjunction of conditions. Or of no conditions is false.
+func Or(conds ...Cond) Cond {
+	if len(conds) == 0 {
+		return condFunc(func(b *builder, _ bool) {
+			b.buf.WriteString("false")
+		})
+	}
+	return join(" OR ", conds)
+}
+
+// Not returns negation of condition c.
+func Not(c Cond) Cond {
+	return condFunc(func(b *builder, _ bool) {
+		b.buf.WriteString("NOT (")
+		c.write(b, false)
+		b.buf.WriteByte(')')
+	})
+}
+
+type order struct {
+	column string
+	desc   bool
+}
+
+// SelectBuilder builds SELECT query.
+type SelectBuilder struct {
+	table   string
+	columns []string
+	where   Cond
+	orderBy []order
+	limit   *uint64
+	offset  *uint64
+}
+
+// Select starts SELECT query of given columns from table.
+// All columns are selected if no columns given.
+func Select(table string, columns ...string) *SelectBuilder {
+	return &SelectBuilder{
+		table:   table,
+		columns: columns,
+	}
+}
+
+// Where sets condition of the query.
+func (s *SelectBuilder) Where(c Cond) *SelectBuilder {
+	s.where = c
+	return s
+}
+
+// OrderBy appends column to ORDER BY clause in ascending order.
+func (s *SelectBuilder) OrderBy(column string) *SelectBuilder {
+	s.orderBy = append(s.orderBy, order{column: column})
+	return s
+}
+
+// OrderByDesc appends column to ORDER BY clause in descending order.
+func (s *SelectBuilder) OrderByDesc(column string) *SelectBuilder {
+	s.orderBy = append(s.orderBy, order{column: column, desc: true})
+	return s
+}
+
+// Limit sets LIMIT of the query.
+func (s *SelectBuilder) Limit(n uint64) *SelectBuilder {
+	s.limit = &n
+	return s
+}
+
+// Offset sets OFFSET of the query.
+func (s *SelectBuilder) Offset(n uint64) *SelectBuilder {
+	s.offset = &n
+	return s
+}
+
+// Build returns text of the query and its parameters.
+func (s *SelectBuilder) Build() (string, *table.QueryParameters, error) {
+	var b builder
+	b.buf.WriteString("SELECT ")
+	if len(s.columns) == 0 {
+		b.buf.WriteByte('*')
+	} else {
+		b.names(s.columns)
+	}
+	b.buf.WriteString(" FROM ")
+	b.name(s.table)
+	b.where(s.where)
+	for i, o := range s.orderBy {
+		if i == 0 {
+			b.buf.WriteString(" ORDER BY ")
+		} else {
+			b.buf.WriteString(", ")
+		}
+		b.name(o.column)
+		if o.desc {
+			b.buf.WriteString(" DESC")
+		}
+	}
+	if s.limit != nil {
+		b.buf.WriteString(" LIMIT ")
+		b.param(*s.limit)
+	}
+	if s.offset != nil {
+		b.buf.WriteString(" OFFSET ")
+		b.param(*s.offset)
+	}
+	return b.build()
+}
+
+// WriteBuilder builds UPSERT, REPLACE and INSERT queries.
+type WriteBuilder struct {
+	op    string
+	table string
+	rows  interface{}
+}
+
+// Upsert returns UPSERT query of rows into table.
+// Rows must be a slice of structs or a List<Struct<...>> value.
+func Upsert(table string, rows interface{}) *WriteBuilder {
+	return &WriteBuilder{op: "UPSERT", table: table, rows: rows}
+}
+
+// Replace returns REPLACE query of rows into table.
+// Rows must be a slice of structs or a List<Struct<...>> value.
+func Replace(table string, rows interface{}) *WriteBuilder {
+	return &WriteBuilder{op: "REPLACE", table: table, rows: rows}
+}
+
+// Insert returns INSERT query of rows into table.
+// Rows must be a slice of structs or a List<Struct<...>> value.
+func Insert(table string, rows interface{}) *WriteBuilder {
+	return &WriteBuilder{op: "INSERT", table: table, rows: rows}
+}
+
+// Build returns text of the query and its parameters.
+func (w *WriteBuilder) Build() (string, *table.QueryParameters, error) {
+	rows, err := types.Marshal(w.rows)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, ok := types.TypeOf(rows).(value.ListType); !ok {
+		return "", nil, fmt.Errorf("ydb: query: rows must be a list, got %s", types.TypeOf(rows))
+	}
+	var b builder
+	b.buf.WriteString(w.op)
+	b.buf.WriteString(" INTO ")
+	b.name(w.table)
+	b.buf.WriteString(" SELECT * FROM AS_TABLE(")
+	b.param(rows)
+	b.buf.WriteByte(')')
+	return b.build()
+}
+
+type assignment struct {
+	column string
+	value  interface{}
+}
+
+// UpdateBuilder builds UPDATE query.
+type UpdateBuilder struct {
+	table string
+	set   []assignment
+	where Cond
+}
+
+// Update starts UPDATE query of table.
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{
+		table: table,
+	}
+}
+
+// Set appends assignment of v to column.
+func (u *UpdateBuilder) Set(column string, v interface{}) *UpdateBuilder {
+	u.set = append(u.set, assignment{column: column, value: v})
+	return u
+}
+
+// Where sets condition of the query.
+func (u *UpdateBuilder) Where(c Cond) *UpdateBuilder {
+	u.where = c
+	return u
+}
+
+// Build returns text of the query and its parameters.
+func (u *UpdateBuilder) Build() (string, *table.QueryParameters, error) {
+	if len(u.set) == 0 {
+		return "", nil, fmt.Errorf("ydb: query: update of %q has no assignments", u.table)
+	}
+	var b builder
+	b.buf.WriteString("UPDATE ")
+	b.name(u.table)
+	b.buf.WriteString(" SET ")
+	for i, a := range u.set {
+		if i > 0 {
+			b.buf.WriteString(", ")
+		}
+		b.name(a.column)
+		b.buf.WriteString(" = ")
+		b.param(a.value)
+	}
+	b.where(u.where)
+	return b.build()
+}
+
+// DeleteBuilder builds DELETE query.
+type DeleteBuilder struct {
+	table string
+	where Cond
+}
+
+// Delete starts DELETE query from table.
+func Delete(table string) *DeleteBuilder {
+	return &DeleteBuilder{
+		table: table,
+	}
+}
+
+// Where sets condition of the query.
+func (d *DeleteBuilder) Where(c Cond) *DeleteBuilder {
+	d.where = c
+	return d
+}
+
+// Build returns text of the query and its parameters.
+func (d *DeleteBuilder) Build() (string, *table.QueryParameters, error) {
+	var b builder
+	b.buf.WriteString("DELETE FROM ")
+	b.name(d.table)
+	b.where(d.where)
+	return b.build()
+}
@@ -0,0 +1,140 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type queryBuilder interface {
+	Build() (string, *table.QueryParameters, error)
+}
+
+type user struct {
+	ID   uint64  `ydb:"id"`
+	Name *string `ydb:"name"`
+}
+
+func TestBuild(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		builder queryBuilder
+		exp     string
+		params  string
+	}{
+		{
+			name:    "select all",
+			builder: Select("users"),
+			exp:     "SELECT * FROM `users`;",
+			params:  "()",
+		},
+		{
+			name: "select",
+			builder: Select("dir/users", "id", "name").
+				Where(And(
+					Eq("status", "active"),
+					Or(In("id", []uint64{1, 2}), IsNull("name")),
+					Not(Like("name", "a%")),
+				)).
+				OrderBy("name").
+				OrderByDesc("id").
+				Limit(10).
+				Offset(20),
+			exp: "DECLARE $p0 AS Utf8;\n" +
+				"DECLARE $p1 AS List<Uint64>;\n" +
+				"DECLARE $p2 AS Utf8;\n" +
+				"DECLARE $p3 AS Uint64;\n" +
+				"DECLARE $p4 AS Uint64;\n" +
+				"SELECT `id`, `name` FROM `dir/users` WHERE `status` = $p0 AND (`id` IN $p1 OR `name` IS NULL) " +
+				"AND NOT (`name` LIKE $p2) ORDER BY `name`, `id` DESC LIMIT $p3 OFFSET $p4;",
+		},
+		{
+			name:    "quote",
+			builder: Select("a`b", "c\\d").Where(And()),
+			exp:     "SELECT `c\\\\d` FROM `a\\`b` WHERE true;",
+		},
+		{
+			name:    "upsert",
+			builder: Upsert("users", []user{{ID: 1}}),
+			exp: "DECLARE $p0 AS List<Struct<`id`:Uint64,`name`:Optional<Utf8>>>;\n" +
+				"UPSERT INTO `users` SELECT * FROM AS_TABLE($p0);",
+		},
+		{
+			name:    "replace",
+			builder: Replace("users", types.ZeroValue(types.List(types.Struct(types.StructField("id", types.TypeUint64))))),
+			exp: "DECLARE $p0 AS List<Struct<`id`:Uint64>>;\n" +
+				"REPLACE INTO `users` SELECT * FROM AS_TABLE($p0);",
+		},
+		{
+			name:    "insert",
+			builder: Insert("users", []user{}),
+			exp: "DECLARE $p0 AS List<Struct<`id`:Uint64,`name`:Optional<Utf8>>>;\n" +
+				"INSERT INTO `users` SELECT * FROM AS_TABLE($p0);",
+		},
+		{
+			name: "update",
+			builder: Update("users").
+				Set("name", types.NullValue(types.TypeUTF8)).
+				Set("age", uint32(3)).
+				Where(Ge("id", uint64(10))),
+			exp: "DECLARE $p0 AS Optional<Utf8>;\n" +
+				"DECLARE $p1 AS Uint32;\n" +
+				"DECLARE $p2 AS Uint64;\n" +
+				"UPDATE `users` SET `name` = $p0, `age` = $p1 WHERE `id` >= $p2;",
+		},
+		{
+			name:    "delete",
+			builder: Delete("users").Where(Or(Lt("id", 1), Gt("id", 2), Ne("id", 3), Le("id", 4), IsNotNull("x"))),
+			exp: "DECLARE $p0 AS Int64;\n" +
+				"DECLARE $p1 AS Int64;\n" +
+				"DECLARE $p2 AS Int64;\n" +
+				"DECLARE $p3 AS Int64;\n" +
+				"DELETE FROM `users` WHERE `id` < $p0 OR `id` > $p1 OR `id` != $p2 OR `id` <= $p3 OR `x` IS NOT NULL;",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			q, params, err := test.builder.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q != test.exp {
+				t.Errorf("unexpected query:\n%s\nexp:\n%s", q, test.exp)
+			}
+			n := 0
+			params.Each(func(string, types.Value) { n++ })
+			if test.params != "" && params.String() != test.params {
+				t.Errorf("unexpected params: %s", params)
+			}
+			if exp := strings.Count(q, "DECLARE "); n != exp {
+				t.Errorf("unexpected params count: %d, exp: %d", n, exp)
+			}
+		})
+	}
+}
+
+func TestBuildStableText(t *testing.T) {
+	build := func(ids []uint64, name string) string {
+		q, _, err := Select("users").Where(And(In("id", ids), Eq("name", name))).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q
+	}
+	if a, b := build([]uint64{1}, "a"), build([]uint64{2, 3, 4}, "b"); a != b {
+		t.Errorf("query text depends on values:\n%s\n%s", a, b)
+	}
+}
+
+func TestBuildError(t *testing.T) {
+	for _, b := range []queryBuilder{
+		Select("users").Where(Eq("id", make(chan int))),
+		Upsert("users", user{ID: 1}),
+		Update("users"),
+	} {
+		if _, _, err := b.Build(); err == nil {
+			t.Errorf("expected error for %#v", b)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package resultset
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// WriteJSON writes remaining rows of the current result set of res to w
+// as JSON array of objects keyed by column names.
+// Values are encoded as types.MarshalJSON does.
+//
+// Current result set must be selected by NextResultSet() without columns.
+//
+//     for res.NextResultSet(ctx) {
+//         if err := resultset.WriteJSON(w, res); err != nil {
+//             // handle error
+//         }
+//     }
+func WriteJSON(w io.Writer, res Result) error {
+	return writeJSON(w, res, false)
+}
+
+// WriteJSONLines writes remaining rows of the current result set of res to w
+// in JSON Lines format, i.e. one JSON object keyed by column names per line.
+// Values are encoded as types.MarshalJSON does.
+//
+// Current result set must be selected by NextResultSet() without columns.
+func WriteJSONLines(w io.Writer, res Result) error {
+	return writeJSON(w, res, true)
+}
+
+func writeJSON(w io.Writer, res Result, lines bool) error {
+	var keys [][]byte
+	res.CurrentResultSet().Columns(func(c options.Column) {
+		// Marshaling of string never fails.
+		key, _ := json.Marshal(c.Name)
+		keys = append(keys, key)
+	})
+	var (
+		buf    = bufio.NewWriter(w)
+		values = make([]types.Value, len(keys))
+		ptrs   = make([]interface{}, len(keys))
+	)
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if !lines {
+		_ = buf.WriteByte('[')
+	}
+	for n := 0; res.NextRow(); n++ {
+		if err := res.Scan(ptrs...); err != nil {
+			return err
+		}
+		if n > 0 && !lines {
+			_ = buf.WriteByte(',')
+		}
+		_ = buf.WriteByte('{')
+		for i, v := range values {
+			if i > 0 {
+				_ = buf.WriteByte(',')
+			}
+			data, err := types.MarshalJSON(v)
+			if err != nil {
+				return err
+			}
+			_, _ = buf.Write(keys[i])
+			_ = buf.WriteByte(':')
+			_, _ = buf.Write(data)
+		}
+		_ = buf.WriteByte('}')
+		if lines {
+			_ = buf.WriteByte('\n')
+		}
+	}
+	if err := res.Err(); err != nil {
+		return err
+	}
+	if !lines {
+		_ = buf.WriteByte(']')
+	}
+	return buf.Flush()
+}
@@ -76,7 +76,7 @@ type Result interface {
 	// For optional types use double pointer construction.
 	// For unknown types use interface types.
 	// Supported scanning byte arrays of various length.
-	// For complex yql types: Dict, List, Tuple and own specific scanning logic implement ydb.Scanner with UnmarshalYDB method
+	// For complex yql types: Dict, List, Tuple and own specific scanning logic implement types.Unmarshaler with UnmarshalYDB method
 	// See examples for more detailed information.
 	// Output param - Scanner error
 	Scan(values ...interface{}) error
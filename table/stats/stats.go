@@ -19,6 +19,11 @@ type QueryStats interface {
 	// NextPhase returns next execution phase within query.
 	// If ok flag is false, then there are no more phases and p is invalid.
 	NextPhase() (p QueryPhase, ok bool)
+	// QueryPlan returns query plan if it is collected, e.g. in explain mode
+	// of scan query.
+	QueryPlan() string
+	// QueryAST returns query AST if it is collected.
+	QueryAST() string
 }
 
 // CompilationStats holds query compilation statistics.
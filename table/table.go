@@ -3,11 +3,14 @@ package table
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/operation"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/resultset"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
@@ -25,7 +28,10 @@ type Session interface {
 	DropTable(ctx context.Context, path string, opts ...options.DropTableOption) (err error)
 	AlterTable(ctx context.Context, path string, opts ...options.AlterTableOption) (err error)
 	CopyTable(ctx context.Context, dst, src string, opts ...options.CopyTableOption) (err error)
+	AlterTableAsync(ctx context.Context, path string, opts ...options.AlterTableOption) (op operation.Operation, err error)
+	CopyTableAsync(ctx context.Context, dst, src string, opts ...options.CopyTableOption) (op operation.Operation, err error)
 	Explain(ctx context.Context, query string) (exp DataQueryExplanation, err error)
+	ExplainScanQuery(ctx context.Context, query string) (exp DataQueryExplanation, err error)
 	Prepare(ctx context.Context, query string) (stmt Statement, err error)
 	Execute(ctx context.Context, tx *TransactionControl, query string, params *QueryParameters, opts ...options.ExecuteDataQueryOption) (txr Transaction, r resultset.Result, err error)
 	ExecuteSchemeQuery(ctx context.Context, query string, opts ...options.ExecuteSchemeQueryOption) (err error)
@@ -45,7 +51,38 @@ func (t *TransactionSettings) Settings() *Ydb_Table.TransactionSettings {
 	return &t.settings
 }
 
-// DataQueryExplanation is a result of ExplainDataQuery call.
+// Transaction mode names
+const (
+	TxModeSerializableReadWrite      = "serializable_read_write"
+	TxModeOnlineReadOnly             = "online_read_only"
+	TxModeOnlineReadOnlyInconsistent = "online_read_only_inconsistent"
+	TxModeStaleReadOnly              = "stale_read_only"
+	TxModeUnknown                    = "unknown"
+)
+
+// Mode returns name of transaction mode. Mode of nil settings or settings
+// without mode is TxModeUnknown.
+func (t *TransactionSettings) Mode() string {
+	if t == nil {
+		return TxModeUnknown
+	}
+	switch m := t.settings.TxMode.(type) {
+	case *Ydb_Table.TransactionSettings_SerializableReadWrite:
+		return TxModeSerializableReadWrite
+	case *Ydb_Table.TransactionSettings_OnlineReadOnly:
+		if m.OnlineReadOnly.GetAllowInconsistentReads() {
+			return TxModeOnlineReadOnlyInconsistent
+		}
+		return TxModeOnlineReadOnly
+	case *Ydb_Table.TransactionSettings_StaleReadOnly:
+		return TxModeStaleReadOnly
+	default:
+		return TxModeUnknown
+	}
+}
+
+// DataQueryExplanation is a result of ExplainDataQuery call or scan query
+// execution in explain mode.
 type DataQueryExplanation struct {
 	AST  string
 	Plan string
@@ -58,8 +95,20 @@ type DataQuery interface {
 	YQL() string
 }
 
+// ErrTransactionFinished is returned on use of committed or rolled back
+// transaction.
+var ErrTransactionFinished = errors.New("ydb: table: transaction is already committed or rolled back")
+
 type Transaction interface {
 	ID() string
+	// Session returns session of the transaction.
+	Session() Session
+	// Settings returns settings the transaction was begun with.
+	Settings() *TransactionSettings
+	// IsActive reports whether transaction is not committed or rolled back yet.
+	// Transaction becomes committed after CommitTx call or execution of query
+	// with CommitTx option of transaction control.
+	IsActive() bool
 	Execute(ctx context.Context, query string, params *QueryParameters, opts ...options.ExecuteDataQueryOption) (resultset.Result, error)
 	ExecuteStatement(ctx context.Context, stmt Statement, params *QueryParameters, opts ...options.ExecuteDataQueryOption) (resultset.Result, error)
 	CommitTx(ctx context.Context, opts ...options.CommitTransactionOption) (r resultset.Result, err error)
@@ -98,7 +147,7 @@ func TxSettings(opts ...TxOption) *TransactionSettings {
 func BeginTx(opts ...TxOption) TxControlOption {
 	return func(d *txControlDesc) {
 		s := TxSettings(opts...)
-		d.TxSelector = &Ydb_Table.TransactionControl_BeginTx{
+		d.desc.TxSelector = &Ydb_Table.TransactionControl_BeginTx{
 			BeginTx: &s.settings,
 		}
 	}
@@ -106,15 +155,16 @@ func BeginTx(opts ...TxOption) TxControlOption {
 
 func WithTx(t Transaction) TxControlOption {
 	return func(d *txControlDesc) {
-		d.TxSelector = &Ydb_Table.TransactionControl_TxId{
+		d.desc.TxSelector = &Ydb_Table.TransactionControl_TxId{
 			TxId: t.ID(),
 		}
+		d.tx = t
 	}
 }
 
 func CommitTx() TxControlOption {
 	return func(d *txControlDesc) {
-		d.CommitTx = true
+		d.desc.CommitTx = true
 	}
 }
 
@@ -152,22 +202,28 @@ func WithInconsistentReads() TxOnlineReadOnlyOption {
 }
 
 type (
-	txControlDesc   Ydb_Table.TransactionControl
+	txControlDesc   TransactionControl
 	TxControlOption func(*txControlDesc)
 )
 
 type TransactionControl struct {
 	desc Ydb_Table.TransactionControl
+	tx   Transaction
 }
 
 func (t *TransactionControl) Desc() *Ydb_Table.TransactionControl {
 	return &t.desc
 }
 
+// Tx returns transaction set with WithTx option or nil.
+func (t *TransactionControl) Tx() Transaction {
+	return t.tx
+}
+
 func TxControl(opts ...TxControlOption) *TransactionControl {
 	c := new(TransactionControl)
 	for _, opt := range opts {
-		opt((*txControlDesc)(&c.desc))
+		opt((*txControlDesc)(c))
 	}
 	return c
 }
@@ -230,8 +286,32 @@ func (q *QueryParameters) Add(opts ...ParameterOption) {
 	}
 }
 
+// ValueParam returns parameter option with value v as is.
+// Go values, including implementations of types.ValueMarshaler, must be
+// converted with types.Marshal first, or passed to MarshalParams.
 func ValueParam(name string, v types.Value) ParameterOption {
 	return func(q queryParams) {
 		q[name] = value.ToYDB(v)
 	}
 }
+
+// MarshalParams returns query parameters with values of params marshaled
+// with types.Marshal. Values may implement types.ValueMarshaler.
+//
+//     params, err := table.MarshalParams(map[string]interface{}{
+//         "$id":    id,
+//         "$price": price, // implements types.ValueMarshaler
+//     })
+func MarshalParams(params map[string]interface{}) (*QueryParameters, error) {
+	q := &QueryParameters{
+		m: make(queryParams, len(params)),
+	}
+	for name, v := range params {
+		x, err := types.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("ydb: parameter %q: %w", name, err)
+		}
+		q.m[name] = value.ToYDB(x)
+	}
+	return q, nil
+}
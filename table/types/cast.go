@@ -0,0 +1,495 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/timeutil"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+)
+
+// TypeOf returns type of value v.
+func TypeOf(v Value) Type {
+	return value.TypeOf(v)
+}
+
+// ToGo converts value v into Go value.
+// Primitive values are converted into the same Go types as RawValue getters
+// return, e.g. Int32 into int32, Utf8 into string, Timestamp into time.Time,
+// Interval into time.Duration and Decimal into Decimal.
+// Containers are converted as follows:
+//
+//   Optional<T>   nil for NULL, converted T otherwise
+//   List<T>       []interface{}
+//   Tuple<...>    []interface{}
+//   Struct<...>   map[string]interface{}
+//   Dict<K,V>     map[interface{}]interface{}
+//   Variant<...>  converted filled item
+//   Void          nil
+//
+// ToGo returns error if Dict key is not comparable in Go, e.g. a String key.
+func ToGo(v Value) (interface{}, error) {
+	switch t := TypeOf(v).(type) {
+	case value.PrimitiveType:
+		return primitiveToGo(t, value.Primitive(v))
+
+	case value.DecimalType:
+		return AsDecimal(v)
+
+	case value.OptionalType:
+		item, ok, err := value.OptionalItem(v)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return ToGo(item)
+
+	case value.ListType, value.TupleType:
+		var items []value.V
+		if _, ok := t.(value.ListType); ok {
+			items, _ = value.ListItems(v)
+		} else {
+			items, _ = value.TupleItems(v)
+		}
+		xs := make([]interface{}, len(items))
+		for i, item := range items {
+			x, err := ToGo(item)
+			if err != nil {
+				return nil, err
+			}
+			xs[i] = x
+		}
+		return xs, nil
+
+	case value.StructType:
+		names, items, _ := value.StructItems(v)
+		m := make(map[string]interface{}, len(items))
+		for i, item := range items {
+			x, err := ToGo(item)
+			if err != nil {
+				return nil, err
+			}
+			m[names[i]] = x
+		}
+		return m, nil
+
+	case value.DictType:
+		keys, payloads, _ := value.DictItems(v)
+		m := make(map[interface{}]interface{}, len(keys))
+		for i := range keys {
+			k, err := ToGo(keys[i])
+			if err != nil {
+				return nil, err
+			}
+			if k != nil && !reflect.TypeOf(k).Comparable() {
+				return nil, fmt.Errorf("ydb: dict key of type %s is not comparable", t.Key)
+			}
+			p, err := ToGo(payloads[i])
+			if err != nil {
+				return nil, err
+			}
+			m[k] = p
+		}
+		return m, nil
+
+	case value.VariantType:
+		_, _, item, err := value.VariantItem(v)
+		if err != nil {
+			return nil, err
+		}
+		return ToGo(item)
+
+	case value.VoidType:
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("ydb: unsupported type %s", t)
+	}
+}
+
+func primitiveToGo(t value.PrimitiveType, x interface{}) (interface{}, error) {
+	switch t {
+	case TypeBool:
+		return x.(bool), nil
+	case TypeInt8:
+		return int8(x.(int32)), nil
+	case TypeUint8:
+		return uint8(x.(uint32)), nil
+	case TypeInt16:
+		return int16(x.(int32)), nil
+	case TypeUint16:
+		return uint16(x.(uint32)), nil
+	case TypeInt32, TypeUint32, TypeInt64, TypeUint64, TypeFloat, TypeDouble:
+		return x, nil
+	case TypeDate:
+		return timeutil.UnmarshalDate(x.(uint32)), nil
+	case TypeDatetime:
+		return timeutil.UnmarshalDatetime(x.(uint32)), nil
+	case TypeTimestamp:
+		return timeutil.UnmarshalTimestamp(x.(uint64)), nil
+	case TypeInterval:
+		return timeutil.UnmarshalInterval(x.(int64)), nil
+	case TypeTzDate:
+		return timeutil.UnmarshalTzDate(x.(string))
+	case TypeTzDatetime:
+		return timeutil.UnmarshalTzDatetime(x.(string))
+	case TypeTzTimestamp:
+		return timeutil.UnmarshalTzTimestamp(x.(string))
+	case TypeString:
+		return x.([]byte), nil
+	case TypeUTF8, TypeDyNumber:
+		return x.(string), nil
+	case TypeYSON, TypeJSON, TypeJSONDocument:
+		return []byte(x.(string)), nil
+	case TypeUUID:
+		return x.([16]byte), nil
+	default:
+		return nil, fmt.Errorf("ydb: unsupported type %s", t)
+	}
+}
+
+// castTo unwraps optional value v and converts it into Go value
+// if its type is t.
+func castTo(v Value, t value.PrimitiveType) (interface{}, error) {
+	v, err := unwrap(v, t)
+	if err != nil {
+		return nil, err
+	}
+	if !value.TypesEqual(TypeOf(v), t) {
+		return nil, fmt.Errorf("ydb: cannot cast value of type %s to %s", TypeOf(v), t)
+	}
+	return primitiveToGo(t, value.Primitive(v))
+}
+
+// unwrap unwraps non-NULL optional value v.
+func unwrap(v Value, t fmt.Stringer) (Value, error) {
+	for {
+		if _, ok := TypeOf(v).(value.OptionalType); !ok {
+			return v, nil
+		}
+		item, ok, err := value.OptionalItem(v)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("ydb: cannot cast NULL value to %s", t)
+		}
+		v = item
+	}
+}
+
+// AsBool returns bool stored in v.
+// As all of typed getters it unwraps non-NULL optional values and returns
+// error if v is NULL or has another type.
+func AsBool(v Value) (bool, error) {
+	x, err := castTo(v, TypeBool)
+	if err != nil {
+		return false, err
+	}
+	return x.(bool), nil
+}
+
+func AsInt8(v Value) (int8, error) {
+	x, err := castTo(v, TypeInt8)
+	if err != nil {
+		return 0, err
+	}
+	return x.(int8), nil
+}
+
+func AsUint8(v Value) (uint8, error) {
+	x, err := castTo(v, TypeUint8)
+	if err != nil {
+		return 0, err
+	}
+	return x.(uint8), nil
+}
+
+func AsInt16(v Value) (int16, error) {
+	x, err := castTo(v, TypeInt16)
+	if err != nil {
+		return 0, err
+	}
+	return x.(int16), nil
+}
+
+func AsUint16(v Value) (uint16, error) {
+	x, err := castTo(v, TypeUint16)
+	if err != nil {
+		return 0, err
+	}
+	return x.(uint16), nil
+}
+
+func AsInt32(v Value) (int32, error) {
+	x, err := castTo(v, TypeInt32)
+	if err != nil {
+		return 0, err
+	}
+	return x.(int32), nil
+}
+
+func AsUint32(v Value) (uint32, error) {
+	x, err := castTo(v, TypeUint32)
+	if err != nil {
+		return 0, err
+	}
+	return x.(uint32), nil
+}
+
+func AsInt64(v Value) (int64, error) {
+	x, err := castTo(v, TypeInt64)
+	if err != nil {
+		return 0, err
+	}
+	return x.(int64), nil
+}
+
+func AsUint64(v Value) (uint64, error) {
+	x, err := castTo(v, TypeUint64)
+	if err != nil {
+		return 0, err
+	}
+	return x.(uint64), nil
+}
+
+func AsFloat(v Value) (float32, error) {
+	x, err := castTo(v, TypeFloat)
+	if err != nil {
+		return 0, err
+	}
+	return x.(float32), nil
+}
+
+func AsDouble(v Value) (float64, error) {
+	x, err := castTo(v, TypeDouble)
+	if err != nil {
+		return 0, err
+	}
+	return x.(float64), nil
+}
+
+func AsDate(v Value) (time.Time, error) {
+	x, err := castTo(v, TypeDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return x.(time.Time), nil
+}
+
+func AsDatetime(v Value) (time.Time, error) {
+	x, err := castTo(v, TypeDatetime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return x.(time.Time), nil
+}
+
+func AsTimestamp(v Value) (time.Time, error) {
+	x, err := castTo(v, TypeTimestamp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return x.(time.Time), nil
+}
+
+func AsInterval(v Value) (time.Duration, error) {
+	x, err := castTo(v, TypeInterval)
+	if err != nil {
+		return 0, err
+	}
+	return x.(time.Duration), nil
+}
+
+func AsTzDate(v Value) (time.Time, error) {
+	x, err := castTo(v, TypeTzDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return x.(time.Time), nil
+}
+
+func AsTzDatetime(v Value) (time.Time, error) {
+	x, err := castTo(v, TypeTzDatetime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return x.(time.Time), nil
+}
+
+func AsTzTimestamp(v Value) (time.Time, error) {
+	x, err := castTo(v, TypeTzTimestamp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return x.(time.Time), nil
+}
+
+func AsString(v Value) ([]byte, error) {
+	x, err := castTo(v, TypeString)
+	if err != nil {
+		return nil, err
+	}
+	return x.([]byte), nil
+}
+
+func AsUTF8(v Value) (string, error) {
+	x, err := castTo(v, TypeUTF8)
+	if err != nil {
+		return "", err
+	}
+	return x.(string), nil
+}
+
+func AsYSON(v Value) ([]byte, error) {
+	x, err := castTo(v, TypeYSON)
+	if err != nil {
+		return nil, err
+	}
+	return x.([]byte), nil
+}
+
+func AsJSON(v Value) ([]byte, error) {
+	x, err := castTo(v, TypeJSON)
+	if err != nil {
+		return nil, err
+	}
+	return x.([]byte), nil
+}
+
+func AsUUID(v Value) ([16]byte, error) {
+	x, err := castTo(v, TypeUUID)
+	if err != nil {
+		return [16]byte{}, err
+	}
+	return x.([16]byte), nil
+}
+
+func AsJSONDocument(v Value) ([]byte, error) {
+	x, err := castTo(v, TypeJSONDocument)
+	if err != nil {
+		return nil, err
+	}
+	return x.([]byte), nil
+}
+
+func AsDyNumber(v Value) (string, error) {
+	x, err := castTo(v, TypeDyNumber)
+	if err != nil {
+		return "", err
+	}
+	return x.(string), nil
+}
+
+// AsDecimal returns decimal stored in v.
+func AsDecimal(v Value) (Decimal, error) {
+	v, err := unwrap(v, stringer("Decimal"))
+	if err != nil {
+		return Decimal{}, err
+	}
+	t, b, err := value.Decimal(v)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{
+		Bytes:     b,
+		Precision: t.Precision,
+		Scale:     t.Scale,
+	}, nil
+}
+
+// OptionalItem returns underlying value of Optional value v.
+// It returns false if v is NULL.
+func OptionalItem(v Value) (item Value, ok bool, err error) {
+	x, ok, err := value.OptionalItem(v)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	return x, true, nil
+}
+
+// ListItems returns items of List value v.
+func ListItems(v Value) ([]Value, error) {
+	items, err := value.ListItems(v)
+	if err != nil {
+		return nil, err
+	}
+	return values(items), nil
+}
+
+// TupleItems returns items of Tuple value v.
+func TupleItems(v Value) ([]Value, error) {
+	items, err := value.TupleItems(v)
+	if err != nil {
+		return nil, err
+	}
+	return values(items), nil
+}
+
+// StructItem is a field of Struct value.
+type StructItem struct {
+	Name  string
+	Value Value
+}
+
+// StructItems returns fields of Struct value v in order of its type fields.
+func StructItems(v Value) ([]StructItem, error) {
+	names, items, err := value.StructItems(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]StructItem, len(items))
+	for i, item := range items {
+		fields[i] = StructItem{
+			Name:  names[i],
+			Value: item,
+		}
+	}
+	return fields, nil
+}
+
+// DictItem is a key-payload pair of Dict value.
+type DictItem struct {
+	Key     Value
+	Payload Value
+}
+
+// DictItems returns pairs of Dict value v.
+func DictItems(v Value) ([]DictItem, error) {
+	keys, payloads, err := value.DictItems(v)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]DictItem, len(keys))
+	for i := range keys {
+		pairs[i] = DictItem{
+			Key:     keys[i],
+			Payload: payloads[i],
+		}
+	}
+	return pairs, nil
+}
+
+// VariantItem returns filled item of Variant value v.
+// It returns non-empty name of the item for struct-based variant.
+func VariantItem(v Value) (name string, index uint32, item Value, err error) {
+	name, index, x, err := value.VariantItem(v)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	return name, index, x, nil
+}
+
+type stringer string
+
+func (s stringer) String() string {
+	return string(s)
+}
+
+func values(vs []value.V) []Value {
+	xs := make([]Value, len(vs))
+	for i, v := range vs {
+		xs[i] = v
+	}
+	return xs
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestToGo(t *testing.T) {
+	ts := time.Unix(1600000000, 123000)
+	for _, test := range []struct {
+		v   Value
+		exp interface{}
+	}{
+		{v: BoolValue(true), exp: true},
+		{v: Int8Value(-8), exp: int8(-8)},
+		{v: Uint16Value(16), exp: uint16(16)},
+		{v: Int64Value(64), exp: int64(64)},
+		{v: DoubleValue(1.5), exp: 1.5},
+		{v: TimestampValueFromTime(ts), exp: ts},
+		{v: IntervalValueFromDuration(time.Second), exp: time.Second},
+		{v: StringValueFromString("bytes"), exp: []byte("bytes")},
+		{v: UTF8Value("text"), exp: "text"},
+		{v: JSONValue(`{"a":1}`), exp: []byte(`{"a":1}`)},
+		{v: NullValue(TypeInt32), exp: nil},
+		{v: OptionalValue(OptionalValue(Int32Value(42))), exp: int32(42)},
+		{v: VoidValue(), exp: nil},
+		{
+			v:   ListValue(Int32Value(1), Int32Value(2)),
+			exp: []interface{}{int32(1), int32(2)},
+		},
+		{
+			v:   TupleValue(Int32Value(1), UTF8Value("2")),
+			exp: []interface{}{int32(1), "2"},
+		},
+		{
+			v: StructValue(
+				StructFieldValue("a", Int32Value(1)),
+				StructFieldValue("b", NullValue(TypeUTF8)),
+			),
+			exp: map[string]interface{}{"a": int32(1), "b": nil},
+		},
+		{
+			v:   DictValue(UTF8Value("a"), Int32Value(1), UTF8Value("b"), Int32Value(2)),
+			exp: map[interface{}]interface{}{"a": int32(1), "b": int32(2)},
+		},
+		{
+			v:   VariantValue(Int32Value(42), 1, Variant(Tuple(TypeUTF8, TypeInt32))),
+			exp: int32(42),
+		},
+	} {
+		t.Run(test.v.(interface{ String() string }).String(), func(t *testing.T) {
+			act, err := ToGo(test.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(act, test.exp) {
+				t.Errorf("unexpected value: %#v, exp: %#v", act, test.exp)
+			}
+		})
+	}
+	if _, err := ToGo(DictValue(StringValueFromString("a"), Int32Value(1))); err == nil {
+		t.Error("expected error for not comparable dict key")
+	}
+}
+
+func TestTypedGetters(t *testing.T) {
+	if v, err := AsInt32(OptionalValue(Int32Value(42))); err != nil || v != 42 {
+		t.Errorf("unexpected result: %v, %v", v, err)
+	}
+	if _, err := AsInt32(NullValue(TypeInt32)); err == nil {
+		t.Error("expected error for NULL value")
+	}
+	if _, err := AsInt64(Int32Value(42)); err == nil {
+		t.Error("expected error for type mismatch")
+	}
+	if v, err := AsUTF8(UTF8Value("text")); err != nil || v != "text" {
+		t.Errorf("unexpected result: %v, %v", v, err)
+	}
+	d, err := AsDecimal(OptionalValue(DecimalValue(&Decimal{Bytes: [16]byte{15: 123}, Precision: 22, Scale: 2})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := d.String(); s != "1.23" {
+		t.Errorf("unexpected decimal: %s", s)
+	}
+}
+
+func TestContainerItems(t *testing.T) {
+	fields, err := StructItems(StructValue(
+		StructFieldValue("a", Int32Value(1)),
+		StructFieldValue("b", UTF8Value("2")),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 || fields[0].Name != "a" || fields[1].Name != "b" {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+	pairs, err := DictItems(DictValue(UTF8Value("a"), Int32Value(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, _ := AsUTF8(pairs[0].Key); k != "a" {
+		t.Errorf("unexpected key: %v", k)
+	}
+	name, index, item, err := VariantItem(VariantValue(Int32Value(42), 1, Variant(Struct(
+		StructField("foo", TypeUTF8),
+		StructField("bar", TypeInt32),
+	))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "bar" || index != 1 || !reflect.DeepEqual(TypeOf(item), TypeInt32) {
+		t.Errorf("unexpected variant item: %s, %d, %v", name, index, item)
+	}
+	if _, ok, err := OptionalItem(NullValue(TypeInt32)); ok || err != nil {
+		t.Errorf("unexpected optional item: %v, %v", ok, err)
+	}
+	if _, err = ListItems(Int32Value(1)); err == nil {
+		t.Error("expected error for type mismatch")
+	}
+}
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+)
+
+// Decimal is a value of Decimal(Precision,Scale) type.
+//
+// Bytes is a big-endian 128 bit signed integer which is the value multiplied
+// by 10^Scale. Besides regular values Decimal may be +inf, -inf and nan,
+// which YDB returns e.g. on overflow.
+//
+// Decimal may be scanned directly with Result.Scan(&d) and passed as query
+// parameter with DecimalValue(&d).
+type Decimal struct {
+	Bytes     [16]byte
+	Precision uint32
+	Scale     uint32
+}
+
+// ParseDecimal parses decimal of given precision and scale from string s
+// such as "-1.23", "inf", "-inf" or "nan".
+// Extra fractional digits are rounded half to even.
+// Values which do not fit into precision become +inf or -inf.
+func ParseDecimal(s string, precision, scale uint32) (Decimal, error) {
+	x, err := decimal.Parse(s, precision, scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return NewDecimal(x, precision, scale), nil
+}
+
+// NewDecimal returns decimal of given precision and scale from x,
+// which is the value multiplied by 10^scale.
+// Values which do not fit into precision become +inf or -inf.
+func NewDecimal(x *big.Int, precision, scale uint32) Decimal {
+	return Decimal{
+		Bytes:     decimal.BigIntToByte(x, precision, scale),
+		Precision: precision,
+		Scale:     scale,
+	}
+}
+
+// DecimalFromRat returns decimal of given precision and scale from x
+// rounded half to even.
+// Values which do not fit into precision become +inf or -inf.
+func DecimalFromRat(x *big.Rat, precision, scale uint32) Decimal {
+	return NewDecimal(roundRat(x, scale), precision, scale)
+}
+
+// String returns text representation of d with Scale fractional digits,
+// e.g. "1.230000000", "inf", "-inf" or "nan".
+func (d Decimal) String() string {
+	return decimal.Format(d.BigInt(), d.Precision, d.Scale)
+}
+
+// BigInt returns value of d multiplied by 10^Scale.
+func (d Decimal) BigInt() *big.Int {
+	return decimal.FromInt128(d.Bytes, d.Precision, d.Scale)
+}
+
+// Rat returns value of d. It returns nil if d is inf or nan.
+func (d Decimal) Rat() *big.Rat {
+	x := d.BigInt()
+	if decimal.IsInf(x) || decimal.IsNaN(x) {
+		return nil
+	}
+	return new(big.Rat).SetFrac(x, pow10(d.Scale))
+}
+
+// IsInf reports whether d is +inf (sign > 0), -inf (sign < 0) or any of them (sign == 0).
+func (d Decimal) IsInf(sign int) bool {
+	x := d.BigInt()
+	return decimal.IsInf(x) && (sign == 0 || sign*x.Sign() > 0)
+}
+
+// IsNaN reports whether d is nan.
+func (d Decimal) IsNaN() bool {
+	return decimal.IsNaN(d.BigInt())
+}
+
+// Sign returns -1, 0 or +1 depending on sign of d.
+// It returns 0 for nan.
+func (d Decimal) Sign() int {
+	if d.IsNaN() {
+		return 0
+	}
+	return d.BigInt().Sign()
+}
+
+// Cmp compares values of d and x which may have different scales and returns
+// -1 if d < x, 0 if d == x and +1 if d > x.
+// Infinities are less and greater than any other values, nan is greater than
+// any other value except nan.
+func (d Decimal) Cmp(x Decimal) int {
+	switch dNaN, xNaN := d.IsNaN(), x.IsNaN(); {
+	case dNaN && xNaN:
+		return 0
+	case dNaN:
+		return 1
+	case xNaN:
+		return -1
+	}
+	a, b := d.Rat(), x.Rat()
+	if a != nil && b != nil {
+		return a.Cmp(b)
+	}
+	switch ra, rb := rank(d), rank(x); {
+	case ra < rb:
+		return -1
+	case ra > rb:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// rank orders infinities and regular values.
+func rank(d Decimal) int {
+	switch {
+	case d.IsInf(-1):
+		return -1
+	case d.IsInf(1):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Round returns d rounded half to even to given number of fractional digits.
+// Precision and scale of result are the same as of d.
+func (d Decimal) Round(scale uint32) Decimal {
+	r := d.Rat()
+	if r == nil || scale >= d.Scale {
+		return d
+	}
+	x := roundRat(r, scale)
+	x.Mul(x, pow10(d.Scale-scale))
+	return NewDecimal(x, d.Precision, d.Scale)
+}
+
+// Rescale converts d into decimal of given precision and scale.
+// Extra fractional digits are rounded half to even.
+// Values which do not fit into precision become +inf or -inf.
+func (d Decimal) Rescale(precision, scale uint32) Decimal {
+	r := d.Rat()
+	if r == nil {
+		return NewDecimal(d.BigInt(), precision, scale)
+	}
+	return DecimalFromRat(r, precision, scale)
+}
+
+// roundRat returns x multiplied by 10^scale and rounded half to even.
+func roundRat(x *big.Rat, scale uint32) *big.Int {
+	n := new(big.Int).Mul(x.Num(), pow10(scale))
+	q, r := new(big.Int).QuoRem(n, x.Denom(), new(big.Int))
+	r.Abs(r).Lsh(r, 1)
+	if c := r.Cmp(x.Denom()); c > 0 || c == 0 && q.Bit(0) == 1 {
+		q.Add(q, big.NewInt(int64(x.Sign())))
+	}
+	return q
+}
+
+func pow10(n uint32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
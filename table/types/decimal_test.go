@@ -0,0 +1,129 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		p, sc uint32
+		exp   string
+	}{
+		{s: "1.23", p: 22, sc: 9, exp: "1.230000000"},
+		{s: "-0.5", p: 22, sc: 9, exp: "-0.500000000"},
+		{s: "0.125", p: 5, sc: 2, exp: "0.12"},
+		{s: "0.135", p: 5, sc: 2, exp: "0.14"},
+		{s: "1000", p: 5, sc: 2, exp: "inf"},
+		{s: "-1000", p: 5, sc: 2, exp: "-inf"},
+		{s: "inf", p: 22, sc: 9, exp: "inf"},
+		{s: "-inf", p: 22, sc: 9, exp: "-inf"},
+		{s: "nan", p: 22, sc: 9, exp: "nan"},
+	} {
+		d, err := ParseDecimal(test.s, test.p, test.sc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act := d.String(); act != test.exp {
+			t.Errorf("unexpected %q(%d,%d): %s, exp: %s", test.s, test.p, test.sc, act, test.exp)
+		}
+		// Value must be the same after conversion to YDB value and back.
+		v, err := AsDecimal(DecimalValue(&d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.String() != test.exp {
+			t.Errorf("unexpected decimal after conversion: %s, exp: %s", v, test.exp)
+		}
+	}
+	if _, err := ParseDecimal("1.2.3", 22, 9); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDecimalRat(t *testing.T) {
+	d := DecimalFromRat(big.NewRat(-1, 3), 22, 9)
+	if exp := "-0.333333333"; d.String() != exp {
+		t.Errorf("unexpected decimal: %s, exp: %s", d, exp)
+	}
+	if r := d.Rat(); r.Cmp(big.NewRat(-333333333, 1000000000)) != 0 {
+		t.Errorf("unexpected rat: %s", r)
+	}
+	if exp := big.NewInt(-333333333); d.BigInt().Cmp(exp) != 0 {
+		t.Errorf("unexpected big int: %s", d.BigInt())
+	}
+	inf, _ := ParseDecimal("inf", 22, 9)
+	if inf.Rat() != nil || !inf.IsInf(0) || !inf.IsInf(1) || inf.IsInf(-1) {
+		t.Errorf("unexpected inf: %s", inf)
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		scale uint32
+		exp   string
+	}{
+		{s: "1.2345", scale: 2, exp: "1.230000000"},
+		{s: "1.235", scale: 2, exp: "1.240000000"},
+		{s: "1.245", scale: 2, exp: "1.240000000"},
+		{s: "-1.245", scale: 2, exp: "-1.240000000"},
+		{s: "-1.2451", scale: 2, exp: "-1.250000000"},
+		{s: "2.5", scale: 0, exp: "2.000000000"},
+		{s: "2.5", scale: 10, exp: "2.500000000"},
+		{s: "-inf", scale: 0, exp: "-inf"},
+	} {
+		d, err := ParseDecimal(test.s, 22, 9)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act := d.Round(test.scale).String(); act != test.exp {
+			t.Errorf("unexpected %s rounded to %d: %s, exp: %s", test.s, test.scale, act, test.exp)
+		}
+	}
+	d, _ := ParseDecimal("123.456", 22, 9)
+	if act, exp := d.Rescale(5, 2).String(), "123.46"; act != exp {
+		t.Errorf("unexpected rescaled: %s, exp: %s", act, exp)
+	}
+	if act, exp := d.Rescale(4, 2).String(), "inf"; act != exp {
+		t.Errorf("unexpected rescaled: %s, exp: %s", act, exp)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	parse := func(s string, scale uint32) Decimal {
+		d, err := ParseDecimal(s, 22, scale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	ordered := []Decimal{
+		parse("-inf", 9),
+		parse("-1.5", 9),
+		parse("-1", 0),
+		parse("0", 2),
+		parse("0.001", 9),
+		parse("1", 1),
+		parse("inf", 9),
+		parse("nan", 9),
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			exp := 0
+			switch {
+			case i < j:
+				exp = -1
+			case i > j:
+				exp = 1
+			}
+			if act := a.Cmp(b); act != exp {
+				t.Errorf("unexpected %s cmp %s: %d, exp: %d", a, b, act, exp)
+			}
+		}
+	}
+	if parse("1.5", 1).Cmp(parse("1.50", 9)) != 0 {
+		t.Error("expected equal decimals of different scales")
+	}
+}
@@ -0,0 +1,367 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ValueMarshaler is implemented by Go types which can represent themselves
+// as YDB value, e.g. money or enumeration types.
+type ValueMarshaler interface {
+	MarshalYDB() (Value, error)
+}
+
+// Unmarshaler is implemented by Go types which can be scanned from
+// YDB value with Result.Scan().
+// UnmarshalList, UnmarshalStruct and UnmarshalDict help to scan containers.
+type Unmarshaler interface {
+	UnmarshalYDB(raw RawValue) error
+}
+
+// Scanner is an old name of Unmarshaler.
+type Scanner = Unmarshaler
+
+var (
+	valueMarshalerType = reflect.TypeOf((*ValueMarshaler)(nil)).Elem()
+	timeType           = reflect.TypeOf(time.Time{})
+	durationType       = reflect.TypeOf(time.Duration(0))
+	decimalType        = reflect.TypeOf(Decimal{})
+	bytesType          = reflect.TypeOf([]byte(nil))
+	uuidType           = reflect.TypeOf([16]byte{})
+)
+
+// Marshal returns YDB value of Go value v.
+//
+// Values implementing ValueMarshaler, with value or pointer receiver, are
+// marshaled with MarshalYDB. Pointers are handled before, so a pointer
+// to such value is Optional of marshaled value and nil pointer is NULL of
+// the type of marshaled zero value.
+// Other Go types are mapped as follows:
+//
+//   bool                           Bool
+//   int8, ..., int64, int          Int8, ..., Int64, Int64
+//   uint8, ..., uint64, uint       Uint8, ..., Uint64, Uint64
+//   float32, float64               Float, Double
+//   string                         Utf8
+//   []byte                         String
+//   [16]byte                       Uuid
+//   time.Time                      Timestamp
+//   time.Duration                  Interval
+//   Decimal                        Decimal
+//   *T                             Optional<T>, nil is NULL
+//   []T                            List<T>
+//   map[K]V                        Dict<K,V>
+//   struct                         Struct with fields of exported struct fields
+//
+// Struct field name may be overridden with `ydb:"name"` tag,
+// fields with `ydb:"-"` tag are skipped.
+// Marshal of slice of structs returns List<Struct<...>> which may be used
+// as rows of BulkUpsert.
+//
+// Note that ValueMarshaler is used by Marshal and table.MarshalParams only:
+// value constructors of this package, table.ValueParam and BulkUpsert accept
+// Value and do not call MarshalYDB.
+func Marshal(v interface{}) (Value, error) {
+	if v == nil {
+		return nil, fmt.Errorf("ydb: cannot marshal nil")
+	}
+	if x, ok := v.(Value); ok {
+		return x, nil
+	}
+	return marshal(reflect.ValueOf(v))
+}
+
+func marshal(rv reflect.Value) (Value, error) {
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			t, err := goType(rv.Type().Elem())
+			if err != nil {
+				return nil, err
+			}
+			return NullValue(t), nil
+		}
+		v, err := marshal(rv.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return OptionalValue(v), nil
+	}
+	if rv.Type().Implements(valueMarshalerType) {
+		return rv.Interface().(ValueMarshaler).MarshalYDB()
+	}
+	if reflect.PtrTo(rv.Type()).Implements(valueMarshalerType) {
+		// MarshalYDB has pointer receiver.
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		return ptr.Interface().(ValueMarshaler).MarshalYDB()
+	}
+	switch rv.Type() {
+	case timeType:
+		return TimestampValueFromTime(rv.Interface().(time.Time)), nil
+	case durationType:
+		return IntervalValueFromDuration(time.Duration(rv.Int())), nil
+	case decimalType:
+		d := rv.Interface().(Decimal)
+		return DecimalValue(&d), nil
+	case bytesType:
+		return StringValue(rv.Bytes()), nil
+	case uuidType:
+		return UUIDValue(rv.Interface().([16]byte)), nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return BoolValue(rv.Bool()), nil
+	case reflect.Int8:
+		return Int8Value(int8(rv.Int())), nil
+	case reflect.Int16:
+		return Int16Value(int16(rv.Int())), nil
+	case reflect.Int32:
+		return Int32Value(int32(rv.Int())), nil
+	case reflect.Int64, reflect.Int:
+		return Int64Value(rv.Int()), nil
+	case reflect.Uint8:
+		return Uint8Value(uint8(rv.Uint())), nil
+	case reflect.Uint16:
+		return Uint16Value(uint16(rv.Uint())), nil
+	case reflect.Uint32:
+		return Uint32Value(uint32(rv.Uint())), nil
+	case reflect.Uint64, reflect.Uint:
+		return Uint64Value(rv.Uint()), nil
+	case reflect.Float32:
+		return FloatValue(float32(rv.Float())), nil
+	case reflect.Float64:
+		return DoubleValue(rv.Float()), nil
+	case reflect.String:
+		return UTF8Value(rv.String()), nil
+
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			t, err := goType(rv.Type().Elem())
+			if err != nil {
+				return nil, err
+			}
+			return ZeroValue(List(t)), nil
+		}
+		items := make([]Value, rv.Len())
+		for i := range items {
+			v, err := marshal(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 && !Equal(TypeOf(v), TypeOf(items[0])) {
+				return nil, fmt.Errorf(
+					"ydb: list items have different types: %s and %s",
+					TypeOf(items[0]), TypeOf(v),
+				)
+			}
+			items[i] = v
+		}
+		return ListValue(items...), nil
+
+	case reflect.Map:
+		if rv.Len() == 0 {
+			k, err := goType(rv.Type().Key())
+			if err != nil {
+				return nil, err
+			}
+			v, err := goType(rv.Type().Elem())
+			if err != nil {
+				return nil, err
+			}
+			return ZeroValue(Dict(k, v)), nil
+		}
+		pairs := make([]Value, 0, 2*rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k, err := marshal(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			v, err := marshal(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			if len(pairs) > 0 && (!Equal(TypeOf(k), TypeOf(pairs[0])) || !Equal(TypeOf(v), TypeOf(pairs[1]))) {
+				return nil, fmt.Errorf("ydb: dict items have different types")
+			}
+			pairs = append(pairs, k, v)
+		}
+		return DictValue(pairs...), nil
+
+	case reflect.Struct:
+		fields := structFields(rv.Type())
+		opts := make([]StructValueOption, len(fields))
+		for i, f := range fields {
+			v, err := marshal(rv.Field(f.index))
+			if err != nil {
+				return nil, fmt.Errorf("ydb: field %q: %w", f.name, err)
+			}
+			opts[i] = StructFieldValue(f.name, v)
+		}
+		return StructValue(opts...), nil
+	}
+	return nil, fmt.Errorf("ydb: cannot marshal %s", rv.Type())
+}
+
+// goType returns YDB type for values of Go type t.
+// It is used to get types of NULL values and empty containers.
+func goType(t reflect.Type) (Type, error) {
+	if t.Kind() == reflect.Ptr {
+		item, err := goType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Optional(item), nil
+	}
+	if reflect.PtrTo(t).Implements(valueMarshalerType) {
+		// Type is taken from marshaled zero value.
+		v, err := reflect.New(t).Interface().(ValueMarshaler).MarshalYDB()
+		if err != nil {
+			return nil, err
+		}
+		return TypeOf(v), nil
+	}
+	switch t {
+	case timeType:
+		return TypeTimestamp, nil
+	case durationType:
+		return TypeInterval, nil
+	case bytesType:
+		return TypeString, nil
+	case uuidType:
+		return TypeUUID, nil
+	case decimalType:
+		return nil, fmt.Errorf("ydb: cannot get precision and scale of NULL %s", t)
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return TypeBool, nil
+	case reflect.Int8:
+		return TypeInt8, nil
+	case reflect.Int16:
+		return TypeInt16, nil
+	case reflect.Int32:
+		return TypeInt32, nil
+	case reflect.Int64, reflect.Int:
+		return TypeInt64, nil
+	case reflect.Uint8:
+		return TypeUint8, nil
+	case reflect.Uint16:
+		return TypeUint16, nil
+	case reflect.Uint32:
+		return TypeUint32, nil
+	case reflect.Uint64, reflect.Uint:
+		return TypeUint64, nil
+	case reflect.Float32:
+		return TypeFloat, nil
+	case reflect.Float64:
+		return TypeDouble, nil
+	case reflect.String:
+		return TypeUTF8, nil
+	case reflect.Slice, reflect.Array:
+		item, err := goType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return List(item), nil
+	case reflect.Map:
+		k, err := goType(t.Key())
+		if err != nil {
+			return nil, err
+		}
+		v, err := goType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Dict(k, v), nil
+	case reflect.Struct:
+		fields := structFields(t)
+		opts := make([]StructOption, len(fields))
+		for i, f := range fields {
+			ft, err := goType(t.Field(f.index).Type)
+			if err != nil {
+				return nil, err
+			}
+			opts[i] = StructField(f.name, ft)
+		}
+		return Struct(opts...), nil
+	}
+	return nil, fmt.Errorf("ydb: cannot get type of %s", t)
+}
+
+type structField struct {
+	name  string
+	index int
+}
+
+func structFields(t reflect.Type) (fields []structField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// Unexported field.
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("ydb"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag = strings.Split(tag, ",")[0]; tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, structField{
+			name:  name,
+			index: i,
+		})
+	}
+	return fields
+}
+
+// UnmarshalList calls f for each item of List value under raw.
+// Item is selected as current value of raw during the call.
+func UnmarshalList(raw RawValue, f func(i int) error) error {
+	n := raw.ListIn()
+	for i := 0; i < n; i++ {
+		raw.ListItem(i)
+		if err := f(i); err != nil {
+			return err
+		}
+	}
+	raw.ListOut()
+	return raw.Err()
+}
+
+// UnmarshalStruct calls f for each field of Struct value under raw.
+// Field is selected as current value of raw during the call.
+func UnmarshalStruct(raw RawValue, f func(name string) error) error {
+	n := raw.StructIn()
+	for i := 0; i < n; i++ {
+		if err := f(raw.StructField(i)); err != nil {
+			return err
+		}
+	}
+	raw.StructOut()
+	return raw.Err()
+}
+
+// UnmarshalDict calls key and payload for each pair of Dict value under raw.
+// Key and payload of the pair are selected as current value of raw during
+// the calls.
+func UnmarshalDict(raw RawValue, key, payload func(i int) error) error {
+	n := raw.DictIn()
+	for i := 0; i < n; i++ {
+		raw.DictKey(i)
+		if err := key(i); err != nil {
+			return err
+		}
+		raw.DictPayload(i)
+		if err := payload(i); err != nil {
+			return err
+		}
+	}
+	raw.DictOut()
+	return raw.Err()
+}
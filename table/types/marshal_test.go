@@ -0,0 +1,100 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+type enum int
+
+func (e enum) MarshalYDB() (Value, error) {
+	return UTF8Value([]string{"unknown", "active"}[e]), nil
+}
+
+type code string
+
+func (c *code) MarshalYDB() (Value, error) {
+	return StringValue([]byte(*c)), nil
+}
+
+type row struct {
+	ID      uint64 `ydb:"id"`
+	Title   *string
+	Status  enum             `ydb:"status"`
+	Tags    []string         `ydb:"tags"`
+	Attrs   map[string]int32 `ydb:"attrs"`
+	At      time.Time        `ydb:"at"`
+	Skipped string           `ydb:"-"`
+	hidden  string
+}
+
+func TestMarshal(t *testing.T) {
+	var (
+		title  = "a"
+		active = enum(1)
+		c      = code("x")
+	)
+	for _, test := range []struct {
+		v   interface{}
+		exp string
+	}{
+		{v: true, exp: `true`},
+		{v: int8(-1), exp: `Int8("-1")`},
+		{v: 1, exp: `Int64("1")`},
+		{v: uint(1), exp: `Uint64("1")`},
+		{v: float32(0.5), exp: `Float("0.5")`},
+		{v: "a", exp: `Utf8("a")`},
+		{v: []byte("a"), exp: `String("a")`},
+		{v: [16]byte{15: 1}, exp: `Uuid("00000000-0000-0000-0000-000000000001")`},
+		{v: time.Duration(0), exp: `Interval("PT0S")`},
+		{v: time.Unix(1, 0), exp: `Timestamp("1970-01-01T00:00:01.000000Z")`},
+		{v: Int32Value(1), exp: `Int32("1")`},
+		{v: enum(1), exp: `Utf8("active")`},
+		{v: &title, exp: `Just(Utf8("a"))`},
+		{v: (*int32)(nil), exp: `Nothing(Optional<Int32>)`},
+		{v: &active, exp: `Just(Utf8("active"))`},
+		{v: (*enum)(nil), exp: `Nothing(Optional<Utf8>)`},
+		{v: &c, exp: `Just(String("x"))`},
+		{v: (*code)(nil), exp: `Nothing(Optional<String>)`},
+		{v: c, exp: `String("x")`},
+		{v: []code{"x"}, exp: `AsList(String("x"))`},
+		{v: []enum{1, 0}, exp: `AsList(Utf8("active"),Utf8("unknown"))`},
+		{v: []enum{}, exp: `ListCreate(Utf8)`},
+		{v: map[string]bool{"a": true}, exp: `AsDict(AsTuple(Utf8("a"),true))`},
+		{v: map[string]*bool{}, exp: `DictCreate(Utf8,Optional<Bool>)`},
+		{
+			v: []row{{
+				ID:     1,
+				Title:  &title,
+				Status: 1,
+				Attrs:  map[string]int32{},
+				At:     time.Unix(0, 0),
+			}},
+			exp: "AsList(AsStruct(Uint64(\"1\") AS `id`,Just(Utf8(\"a\")) AS `Title`,Utf8(\"active\") AS `status`," +
+				"ListCreate(Utf8) AS `tags`,DictCreate(Utf8,Int32) AS `attrs`,Timestamp(\"1970-01-01T00:00:00.000000Z\") AS `at`))",
+		},
+		{
+			v: []row{},
+			exp: "ListCreate(Struct<`id`:Uint64,`Title`:Optional<Utf8>,`status`:Utf8,`tags`:List<Utf8>," +
+				"`attrs`:Dict<Utf8,Int32>,`at`:Timestamp>)",
+		},
+	} {
+		v, err := Marshal(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act := FormatYQL(v); act != test.exp {
+			t.Errorf("unexpected value of %#v:\n%s\nexp:\n%s", test.v, act, test.exp)
+		}
+	}
+	for _, v := range []interface{}{
+		nil,
+		struct{ C chan int }{},
+		[]interface{}{1, "a"},
+		(*Decimal)(nil),
+	} {
+		if _, err := Marshal(v); err == nil {
+			t.Errorf("expected error for %#v", v)
+		}
+	}
+}
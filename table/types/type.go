@@ -49,6 +49,13 @@ func Struct(opts ...StructOption) Type {
 	return value.StructType(s)
 }
 
+func Dict(key, payload Type) Type {
+	return value.DictType{
+		Key:     key,
+		Payload: payload,
+	}
+}
+
 func Variant(x Type) Type {
 	switch v := x.(type) {
 	case value.TupleType:
@@ -125,6 +132,11 @@ func WriteTypeStringTo(buf *bytes.Buffer, t Type) {
 	value.WriteTypeStringTo(buf, t)
 }
 
+// Equal checks for type equivalence.
+func Equal(a, b Type) bool {
+	return value.TypesEqual(a, b)
+}
+
 // RawValue scanning non-primitive yql types or for own implementation scanner native API
 type RawValue interface {
 	Path() string
@@ -254,7 +266,10 @@ type RawValue interface {
 	Err() error
 }
 
-// Scanner scanning non-primitive yql types
-type Scanner interface {
-	UnmarshalYDB(res RawValue) error
+// ParseType parses YQL type string such as "Optional<List<Struct<a:Int32,b:Utf8>>>".
+// It supports all primitive types, Decimal(p,s), Optional (including T? shorthand),
+// List, Tuple, Struct, Dict, Variant, Void and Tagged.
+// Tags are not kept by Type, so Tagged<T,'tag'> is parsed into T.
+func ParseType(s string) (Type, error) {
+	return value.ParseType(s)
 }
@@ -66,22 +66,6 @@ func NullValue(t Type) Value      { return value.NullValue(t) }
 func ZeroValue(t Type) Value      { return value.ZeroValue(t) }
 func OptionalValue(v Value) Value { return value.OptionalValue(v) }
 
-// Decimal supported in scanner API
-type Decimal struct {
-	Bytes     [16]byte
-	Precision uint32
-	Scale     uint32
-}
-
-func (d *Decimal) String() string {
-	v := decimal.FromInt128(d.Bytes, d.Precision, d.Scale)
-	return decimal.Format(v, d.Precision, d.Scale)
-}
-
-func (d *Decimal) BigInt() *big.Int {
-	return decimal.FromInt128(d.Bytes, d.Precision, d.Scale)
-}
-
 // DecimalValue creates decimal value of given types t and value v.
 // Note that Decimal.Bytes interpreted as big-endian int128.
 func DecimalValue(v *Decimal) Value {
@@ -149,3 +133,64 @@ func VariantValue(v Value, i uint32, variantT Type) Value {
 func Compare(l, r Value) (int, error) {
 	return cmp.Compare(l, r)
 }
+
+// FormatYQL returns YQL literal of v, e.g. Timestamp("2020-01-01T00:00:00.000000Z"),
+// Decimal("1.23",22,9), AsList(Int32("1"),Int32("2")) or Nothing(Optional<Int32>).
+// Result may be inlined into YQL query text or parsed back with ParseYQL.
+func FormatYQL(v Value) string {
+	return value.FormatYQL(v)
+}
+
+// ParseYQL parses YQL literal in format of FormatYQL.
+func ParseYQL(s string) (Value, error) {
+	return value.ParseYQL(s)
+}
+
+// FormatText returns text representation of primitive or decimal value v
+// which is used in its YQL literal, e.g. "2020-01-01" for Date.
+func FormatText(v Value) (string, error) {
+	return value.PrimitiveText(v)
+}
+
+// ParseText parses text representation s of primitive or decimal value of type t.
+// It is the inverse of FormatText.
+func ParseText(t Type, s string) (Value, error) {
+	return value.ParseText(t, s)
+}
+
+// MarshalJSON returns JSON representation of v.
+//
+// Values are mapped as follows:
+//
+//   Bool                           true or false
+//   Int8 ... Int64, Uint8 ... Uint64  number
+//   Float, Double                  number, or "nan", "inf" and "-inf" strings
+//   String                         base64 encoded string
+//   Utf8, Yson, DyNumber           string
+//   Json, JsonDocument             embedded JSON as is
+//   Date, Datetime, Timestamp      string as "2006-01-02", "2006-01-02T15:04:05Z"
+//                                  and "2006-01-02T15:04:05.000000Z"
+//   TzDate, TzDatetime, TzTimestamp  string as is, e.g. "2020-01-01,Europe/Moscow"
+//   Interval                       ISO 8601 duration string, e.g. "P1DT2H3M4.5S"
+//   Uuid                           string as "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+//   Decimal                        string with scale digits, e.g. "1.230000000"
+//   Void                           null
+//   Optional<T>                    null for NULL, T otherwise
+//   Optional<Optional<T>>          null for NULL, [Optional<T>] otherwise
+//   List<T>, Tuple<...>            array
+//   Struct<...>                    object with members in order of fields
+//   Dict<K,V>                      array of [key, payload] pairs
+//   Variant<...>                   [index, item] or ["name", item] for struct-based variant
+//
+// Note that Int64 and Uint64 numbers may lose precision in JavaScript clients.
+func MarshalJSON(v Value) ([]byte, error) {
+	return value.MarshalJSON(v)
+}
+
+// UnmarshalJSON parses JSON representation of value of type t
+// in format of MarshalJSON.
+// Integer numbers are also accepted as strings, e.g. "9007199254740993".
+// Missing members of Struct are treated as NULL if they are optional.
+func UnmarshalJSON(data []byte, t Type) (Value, error) {
+	return value.UnmarshalJSON(data, t)
+}
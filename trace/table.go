@@ -2,6 +2,7 @@ package trace
 
 import (
 	"context"
+	"time"
 )
 
 //go:generate gtrace
@@ -25,10 +26,16 @@ type (
 		OnSessionTransactionBegin    func(SessionTransactionBeginStartInfo) func(SessionTransactionBeginDoneInfo)
 		OnSessionTransactionCommit   func(SessionTransactionCommitStartInfo) func(SessionTransactionCommitDoneInfo)
 		OnSessionTransactionRollback func(SessionTransactionRollbackStartInfo) func(SessionTransactionRollbackDoneInfo)
+		// OnSessionTransaction is called when transaction is begun and is done
+		// when transaction is committed, rolled back or aborted by error of query
+		OnSessionTransaction func(SessionTransactionStartInfo) func(SessionTransactionDoneInfo)
 		// Pool events
-		OnPoolInit  func(PoolInitStartInfo) func(PoolInitDoneInfo)
-		OnPoolClose func(PoolCloseStartInfo) func(PoolCloseDoneInfo)
-		OnPoolRetry func(PoolRetryStartInfo) func(info PoolRetryInternalInfo) func(PoolRetryDoneInfo)
+		OnPoolInit   func(PoolInitStartInfo) func(PoolInitDoneInfo)
+		OnPoolClose  func(PoolCloseStartInfo) func(PoolCloseDoneInfo)
+		OnPoolRetry  func(PoolRetryStartInfo) func(info PoolRetryInternalInfo) func(PoolRetryDoneInfo)
+		OnPoolWarmUp func(PoolWarmUpStartInfo) func(PoolWarmUpDoneInfo)
+		// OnPoolNodeShutdown is called when server hints that node is shutting down
+		OnPoolNodeShutdown func(PoolNodeShutdownStartInfo) func(PoolNodeShutdownDoneInfo)
 		// Pool session lifecycle events
 		OnPoolSessionNew   func(PoolSessionNewStartInfo) func(PoolSessionNewDoneInfo)
 		OnPoolSessionClose func(PoolSessionCloseStartInfo) func(PoolSessionCloseDoneInfo)
@@ -150,6 +157,22 @@ type (
 	SessionTransactionRollbackDoneInfo struct {
 		Error error
 	}
+	SessionTransactionStartInfo struct {
+		Context context.Context
+		Session sessionInfo
+		Tx      transactionInfo
+		// Mode is a name of transaction mode (see table.TransactionSettings.Mode).
+		Mode string
+		// Lazy is true if transaction is begun by query execution with
+		// table.BeginTx transaction control.
+		Lazy bool
+	}
+	SessionTransactionDoneInfo struct {
+		Duration  time.Duration
+		Queries   int
+		Committed bool
+		Error     error
+	}
 	PoolInitStartInfo struct {
 		Context context.Context
 	}
@@ -157,6 +180,30 @@ type (
 		Limit            int
 		KeepAliveMinSize int
 	}
+	PoolWarmUpStartInfo struct {
+		Context     context.Context
+		MinIdleSize int
+		Idle        int
+	}
+	PoolWarmUpDoneInfo struct {
+		Created int
+		Idle    int
+		Error   error
+	}
+	PoolNodeShutdownStartInfo struct {
+		Context context.Context
+		Address string
+	}
+	PoolNodeShutdownDoneInfo struct {
+		// Evicted is a number of closed idle sessions of the node.
+		Evicted int
+		// Marked is a number of busy sessions of the node which will be
+		// closed on returning into the pool.
+		Marked int
+		// Created is a number of sessions created to replace evicted ones.
+		Created int
+		Error   error
+	}
 	PoolSessionNewStartInfo struct {
 		Context context.Context
 	}
@@ -173,11 +220,15 @@ type (
 		Error    error
 	}
 	PoolWaitStartInfo struct {
-		Context context.Context
+		Context  context.Context
+		Priority int
+		// Position is a number of callers waiting before the caller.
+		Position int
 	}
 	PoolWaitDoneInfo struct {
-		Session sessionInfo
-		Error   error
+		Session   sessionInfo
+		QueueTime time.Duration
+		Error     error
 	}
 	PoolTakeStartInfo struct {
 		Context context.Context
@@ -4,6 +4,7 @@ package trace
 
 import (
 	"context"
+	"time"
 )
 
 // Compose returns a new Table which has functional fields composed
@@ -250,6 +251,30 @@ func (t Table) Compose(x Table) (ret Table) {
 		}
 	}
 	switch {
+	case t.OnSessionTransaction == nil:
+		ret.OnSessionTransaction = x.OnSessionTransaction
+	case x.OnSessionTransaction == nil:
+		ret.OnSessionTransaction = t.OnSessionTransaction
+	default:
+		h1 := t.OnSessionTransaction
+		h2 := x.OnSessionTransaction
+		ret.OnSessionTransaction = func(s SessionTransactionStartInfo) func(SessionTransactionDoneInfo) {
+			r1 := h1(s)
+			r2 := h2(s)
+			switch {
+			case r1 == nil:
+				return r2
+			case r2 == nil:
+				return r1
+			default:
+				return func(s SessionTransactionDoneInfo) {
+					r1(s)
+					r2(s)
+				}
+			}
+		}
+	}
+	switch {
 	case t.OnPoolInit == nil:
 		ret.OnPoolInit = x.OnPoolInit
 	case x.OnPoolInit == nil:
@@ -333,6 +358,54 @@ func (t Table) Compose(x Table) (ret Table) {
 		}
 	}
 	switch {
+	case t.OnPoolWarmUp == nil:
+		ret.OnPoolWarmUp = x.OnPoolWarmUp
+	case x.OnPoolWarmUp == nil:
+		ret.OnPoolWarmUp = t.OnPoolWarmUp
+	default:
+		h1 := t.OnPoolWarmUp
+		h2 := x.OnPoolWarmUp
+		ret.OnPoolWarmUp = func(p PoolWarmUpStartInfo) func(PoolWarmUpDoneInfo) {
+			r1 := h1(p)
+			r2 := h2(p)
+			switch {
+			case r1 == nil:
+				return r2
+			case r2 == nil:
+				return r1
+			default:
+				return func(p PoolWarmUpDoneInfo) {
+					r1(p)
+					r2(p)
+				}
+			}
+		}
+	}
+	switch {
+	case t.OnPoolNodeShutdown == nil:
+		ret.OnPoolNodeShutdown = x.OnPoolNodeShutdown
+	case x.OnPoolNodeShutdown == nil:
+		ret.OnPoolNodeShutdown = t.OnPoolNodeShutdown
+	default:
+		h1 := t.OnPoolNodeShutdown
+		h2 := x.OnPoolNodeShutdown
+		ret.OnPoolNodeShutdown = func(p PoolNodeShutdownStartInfo) func(PoolNodeShutdownDoneInfo) {
+			r1 := h1(p)
+			r2 := h2(p)
+			switch {
+			case r1 == nil:
+				return r2
+			case r2 == nil:
+				return r1
+			default:
+				return func(p PoolNodeShutdownDoneInfo) {
+					r1(p)
+					r2(p)
+				}
+			}
+		}
+	}
+	switch {
 	case t.OnPoolSessionNew == nil:
 		ret.OnPoolSessionNew = x.OnPoolSessionNew
 	case x.OnPoolSessionNew == nil:
@@ -639,6 +712,21 @@ func (t Table) onSessionTransactionRollback(s SessionTransactionRollbackStartInf
 	}
 	return res
 }
+func (t Table) onSessionTransaction(s SessionTransactionStartInfo) func(SessionTransactionDoneInfo) {
+	fn := t.OnSessionTransaction
+	if fn == nil {
+		return func(SessionTransactionDoneInfo) {
+			return
+		}
+	}
+	res := fn(s)
+	if res == nil {
+		return func(SessionTransactionDoneInfo) {
+			return
+		}
+	}
+	return res
+}
 func (t Table) onPoolInit(p PoolInitStartInfo) func(PoolInitDoneInfo) {
 	fn := t.OnPoolInit
 	if fn == nil {
@@ -696,6 +784,36 @@ func (t Table) onPoolRetry(p PoolRetryStartInfo) func(info PoolRetryInternalInfo
 		return res
 	}
 }
+func (t Table) onPoolWarmUp(p PoolWarmUpStartInfo) func(PoolWarmUpDoneInfo) {
+	fn := t.OnPoolWarmUp
+	if fn == nil {
+		return func(PoolWarmUpDoneInfo) {
+			return
+		}
+	}
+	res := fn(p)
+	if res == nil {
+		return func(PoolWarmUpDoneInfo) {
+			return
+		}
+	}
+	return res
+}
+func (t Table) onPoolNodeShutdown(p PoolNodeShutdownStartInfo) func(PoolNodeShutdownDoneInfo) {
+	fn := t.OnPoolNodeShutdown
+	if fn == nil {
+		return func(PoolNodeShutdownDoneInfo) {
+			return
+		}
+	}
+	res := fn(p)
+	if res == nil {
+		return func(PoolNodeShutdownDoneInfo) {
+			return
+		}
+	}
+	return res
+}
 func (t Table) onPoolSessionNew(p PoolSessionNewStartInfo) func(PoolSessionNewDoneInfo) {
 	fn := t.OnPoolSessionNew
 	if fn == nil {
@@ -922,6 +1040,23 @@ func TableOnSessionTransactionRollback(t Table, c context.Context, session sessi
 		res(p)
 	}
 }
+func TableOnSessionTransaction(t Table, c context.Context, session sessionInfo, tx transactionInfo, mode string, lazy bool) func(duration time.Duration, queries int, committed bool, _ error) {
+	var p SessionTransactionStartInfo
+	p.Context = c
+	p.Session = session
+	p.Tx = tx
+	p.Mode = mode
+	p.Lazy = lazy
+	res := t.onSessionTransaction(p)
+	return func(duration time.Duration, queries int, committed bool, e error) {
+		var p SessionTransactionDoneInfo
+		p.Duration = duration
+		p.Queries = queries
+		p.Committed = committed
+		p.Error = e
+		res(p)
+	}
+}
 func TableOnPoolInit(t Table, c context.Context) func(limit int, keepAliveMinSize int) {
 	var p PoolInitStartInfo
 	p.Context = c
@@ -960,6 +1095,34 @@ func TableOnPoolRetry(t Table, c context.Context, idempotent bool) func(error) f
 		}
 	}
 }
+func TableOnPoolWarmUp(t Table, c context.Context, minIdleSize int, idle int) func(created int, idle int, _ error) {
+	var p PoolWarmUpStartInfo
+	p.Context = c
+	p.MinIdleSize = minIdleSize
+	p.Idle = idle
+	res := t.onPoolWarmUp(p)
+	return func(created int, idle int, e error) {
+		var p PoolWarmUpDoneInfo
+		p.Created = created
+		p.Idle = idle
+		p.Error = e
+		res(p)
+	}
+}
+func TableOnPoolNodeShutdown(t Table, c context.Context, address string) func(evicted int, marked int, created int, _ error) {
+	var p PoolNodeShutdownStartInfo
+	p.Context = c
+	p.Address = address
+	res := t.onPoolNodeShutdown(p)
+	return func(evicted int, marked int, created int, e error) {
+		var p PoolNodeShutdownDoneInfo
+		p.Evicted = evicted
+		p.Marked = marked
+		p.Created = created
+		p.Error = e
+		res(p)
+	}
+}
 func TableOnPoolSessionNew(t Table, c context.Context) func(session sessionInfo, _ error) {
 	var p PoolSessionNewStartInfo
 	p.Context = c
@@ -1004,13 +1167,16 @@ func TableOnPoolGet(t Table, c context.Context) func(session sessionInfo, attemp
 		res(p)
 	}
 }
-func TableOnPoolWait(t Table, c context.Context) func(session sessionInfo, _ error) {
+func TableOnPoolWait(t Table, c context.Context, priority int, position int) func(session sessionInfo, queueTime time.Duration, _ error) {
 	var p PoolWaitStartInfo
 	p.Context = c
+	p.Priority = priority
+	p.Position = position
 	res := t.onPoolWait(p)
-	return func(session sessionInfo, e error) {
+	return func(session sessionInfo, queueTime time.Duration, e error) {
 		var p PoolWaitDoneInfo
 		p.Session = session
+		p.QueueTime = queueTime
 		p.Error = e
 		res(p)
 	}